@@ -21,7 +21,17 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
-const DefaultResyncPeriod = 12 * time.Hour
+const (
+	DefaultResyncPeriod       = 12 * time.Hour
+	DefaultRestartBackoffBase = 10 * time.Second
+	DefaultRestartBackoffMax  = 5 * time.Minute
+
+	// GangSchedulingBackendVolcano gang-schedules tfjobs with the PodGroups of Volcano.
+	GangSchedulingBackendVolcano = "volcano"
+	// GangSchedulingBackendCoscheduling gang-schedules tfjobs with the PodGroups
+	// of the coscheduling plugin of the scheduler-plugins.
+	GangSchedulingBackendCoscheduling = "coscheduling"
+)
 
 // ServerOption is the main context object for the controller manager.
 type ServerOption struct {
@@ -34,13 +44,32 @@ type ServerOption struct {
 	GangSchedulerName    string
 	Namespace            string
 	MonitoringPort       int
+	WebhookPort          int
+	WebhookCertDir       string
+	WebhookHost          string
 	ResyncPeriod         time.Duration
+	// ClusterDomain is the cluster domain in the cluster spec of the TFJobs,
+	// which can be overridden by the cluster domain annotation of a TFJob.
+	ClusterDomain string
+	// DetectClusterDomain enables detecting the cluster domain from the
+	// resolv.conf of the operator pod if ClusterDomain is not set.
+	DetectClusterDomain bool
+	// RestartBackoffBase is the delay before recreating a replica restarted by
+	// the operator for the first time. It doubles on every restart.
+	RestartBackoffBase time.Duration
+	// RestartBackoffMax is the maximum delay before recreating a restarted replica.
+	RestartBackoffMax time.Duration
 	// QPS indicates the maximum QPS to the master from this client.
 	// If it's zero, the created RESTClient will use DefaultQPS: 5
 	QPS int
 	// Maximum burst for throttle.
 	// If it's zero, the created RESTClient will use DefaultBurst: 10.
 	Burst int
+	// GangSchedulingBackend is the gang scheduler whose PodGroups schedule the
+	// replicas of a TFJob together, see GangSchedulingBackendVolcano and
+	// GangSchedulingBackendCoscheduling. The scheduler name of the pods is
+	// GangSchedulerName, or the default scheduler name of the backend if empty.
+	GangSchedulingBackend string
 }
 
 // NewServerOption creates a new CMServer with a default config.
@@ -70,14 +99,40 @@ func (s *ServerOption) AddFlags(fs *flag.FlagSet) {
 		"Set true to use json style log format. Set false to use plaintext style log format")
 
 	fs.BoolVar(&s.EnableGangScheduling, "enable-gang-scheduling", false, "Set true to enable gang scheduling")
-	fs.StringVar(&s.GangSchedulerName, "gang-scheduler-name", "volcano", "The scheduler to gang-schedule tfjobs, defaults to volcano")
+	fs.StringVar(&s.GangSchedulingBackend, "gang-scheduling-backend", GangSchedulingBackendVolcano,
+		`The PodGroups to gang-schedule tfjobs with, either "volcano" or "coscheduling"
+of the scheduler-plugins.`)
+	fs.StringVar(&s.GangSchedulerName, "gang-scheduler-name", "",
+		`The scheduler to gang-schedule tfjobs, defaults to "volcano" for the volcano backend
+and "scheduler-plugins-scheduler" for the coscheduling backend.`)
 
 	fs.IntVar(&s.MonitoringPort, "monitoring-port", 8443,
 		`Endpoint port for displaying monitoring metrics. 
 It can be set to "0" to disable the metrics serving.`)
 
+	fs.IntVar(&s.WebhookPort, "webhook-port", 0,
+		`Port of the admission webhooks for tfjobs.
+It can be set to "0" to disable the webhook serving.`)
+	fs.StringVar(&s.WebhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		`The directory containing tls.crt and tls.key of the webhook.
+If they don't exist, a self-signed certificate is generated there.`)
+	fs.StringVar(&s.WebhookHost, "webhook-host", "localhost",
+		"The host name the self-signed webhook certificate is issued for.")
+
 	fs.DurationVar(&s.ResyncPeriod, "resyc-period", DefaultResyncPeriod, "Resync interval of the tf-operator")
 
+	fs.StringVar(&s.ClusterDomain, "cluster-domain", "",
+		`The cluster domain in the cluster spec of the TFJobs, such as "cluster.local".
+If it is not set, CUSTOM_CLUSTER_DOMAIN is used. If neither is set, the addresses end with ".svc".`)
+	fs.BoolVar(&s.DetectClusterDomain, "detect-cluster-domain", false,
+		"Detect the cluster domain from the search path in /etc/resolv.conf if neither --cluster-domain nor CUSTOM_CLUSTER_DOMAIN is set.")
+
+	fs.DurationVar(&s.RestartBackoffBase, "restart-backoff-base", DefaultRestartBackoffBase,
+		`The delay before recreating a replica restarted because of its exit code, which doubles on every restart.
+It can be set to "0" to recreate the replicas without delay.`)
+	fs.DurationVar(&s.RestartBackoffMax, "restart-backoff-max", DefaultRestartBackoffMax,
+		"The maximum delay before recreating a replica restarted because of its exit code.")
+
 	fs.IntVar(&s.QPS, "qps", 5, "QPS indicates the maximum QPS to the master from this client.")
 	fs.IntVar(&s.Burst, "burst", 10, "Maximum burst for throttle.")
 }
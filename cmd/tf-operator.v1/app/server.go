@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	restclientset "k8s.io/client-go/rest"
@@ -45,6 +46,7 @@ import (
 	tfjobinformers "github.com/kubeflow/tf-operator/pkg/client/informers/externalversions"
 	controller "github.com/kubeflow/tf-operator/pkg/controller.v1/tensorflow"
 	"github.com/kubeflow/tf-operator/pkg/version"
+	"github.com/kubeflow/tf-operator/pkg/webhook"
 )
 
 const (
@@ -61,6 +63,9 @@ var (
 // RecommendedKubeConfigPathEnv is the environment variable name for kubeconfig.
 const RecommendedKubeConfigPathEnv = "KUBECONFIG"
 
+// resolvConfPath is the path of the resolv.conf to detect the cluster domain.
+const resolvConfPath = "/etc/resolv.conf"
+
 var (
 	isLeader = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "tf_operator_is_leader",
@@ -87,6 +92,18 @@ func Run(opt *options.ServerOption) error {
 		log.Infof("Scoping operator to namespace %s", opt.Namespace)
 	}
 
+	if opt.ClusterDomain == "" {
+		opt.ClusterDomain = os.Getenv(controller.EnvCustomClusterDomain)
+	}
+	if opt.ClusterDomain == "" && opt.DetectClusterDomain {
+		clusterDomain, err := controller.DetectClusterDomain(resolvConfPath)
+		if err != nil {
+			log.Warnf("Failed to detect the cluster domain from %s: %v", resolvConfPath, err)
+		}
+		opt.ClusterDomain = clusterDomain
+	}
+	log.Infof("Using cluster domain %q in the cluster spec of TFJobs", opt.ClusterDomain)
+
 	// To help debugging, immediately log version.
 	log.Infof("%+v", version.Info(apiVersion))
 
@@ -116,7 +133,7 @@ func Run(opt *options.ServerOption) error {
 	// Create clients.
 	kubeClientSet, leaderElectionClientSet,
 		apiextensionClientSet, tfJobClientSet,
-		volcanoClientSet, err := createClientSets(kcfg)
+		volcanoClientSet, dynamicClient, err := createClientSets(kcfg)
 	if err != nil {
 		log.Fatalf("Error create client set : %s", err.Error())
 		return err
@@ -133,7 +150,7 @@ func Run(opt *options.ServerOption) error {
 		kcfg, opt.Namespace, opt.ResyncPeriod)
 
 	// Create tf controller.
-	tc := controller.NewTFController(unstructuredInformer, kubeClientSet, volcanoClientSet, tfJobClientSet, kubeInformerFactory, tfJobInformerFactory, *opt)
+	tc := controller.NewTFController(unstructuredInformer, kubeClientSet, volcanoClientSet, dynamicClient, tfJobClientSet, kubeInformerFactory, tfJobInformerFactory, *opt)
 
 	// Start informer goroutines.
 	go kubeInformerFactory.Start(stopCh)
@@ -143,6 +160,20 @@ func Run(opt *options.ServerOption) error {
 	// go tfJobInformerFactory.Start(stopCh)
 	go unstructuredInformer.Informer().Run(stopCh)
 
+	// Start the admission webhook. It is served by every replica, not only the leader.
+	if opt.WebhookPort != 0 {
+		webhookServer := &webhook.Server{
+			Port:    opt.WebhookPort,
+			CertDir: opt.WebhookCertDir,
+			Host:    opt.WebhookHost,
+		}
+		go func() {
+			if err := webhookServer.Run(stopCh); err != nil {
+				log.Fatalf("Failed to run the webhook server: %v", err)
+			}
+		}()
+	}
+
 	// Set leader election start function.
 	run := func(context.Context) {
 		isLeader.Set(1)
@@ -198,34 +229,39 @@ func Run(opt *options.ServerOption) error {
 func createClientSets(config *restclientset.Config) (
 	kubeclientset.Interface, kubeclientset.Interface,
 	apiextensionclientset.Interface, tfjobclientset.Interface,
-	volcanoclient.Interface, error) {
+	volcanoclient.Interface, dynamic.Interface, error) {
 
 	kubeClientSet, err := kubeclientset.NewForConfig(restclientset.AddUserAgent(config, "tf-operator"))
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, err
 	}
 
 	leaderElectionClientSet, err := kubeclientset.NewForConfig(restclientset.AddUserAgent(config, "leader-election"))
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, err
 	}
 
 	apiextensionClientSet, err := apiextensionclientset.NewForConfig(restclientset.AddUserAgent(config, "leader-election"))
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, err
 	}
 
 	tfJobClientSet, err := tfjobclientset.NewForConfig(config)
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, err
 	}
 
 	volcanoClientSet, err := volcanoclient.NewForConfig(restclientset.AddUserAgent(config, "volcano"))
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, err
 	}
 
-	return kubeClientSet, leaderElectionClientSet, apiextensionClientSet, tfJobClientSet, volcanoClientSet, nil
+	return kubeClientSet, leaderElectionClientSet, apiextensionClientSet, tfJobClientSet, volcanoClientSet, dynamicClient, nil
 }
 
 // checkCRDExists checks if the CRD exists.
@@ -14,10 +14,225 @@
 
 package v1
 
+import (
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
 // SuccessPolicy is the success policy.
 type SuccessPolicy string
 
 const (
-	SuccessPolicyDefault    SuccessPolicy = ""
+	// SuccessPolicyDefault marks the TFJob succeeded when the chief succeeds.
+	// Worker 0 is the chief if there is no Chief or Master replica. The failed
+	// replicas of any type fail the TFJob according to their failure policies.
+	SuccessPolicyDefault SuccessPolicy = ""
+	// SuccessPolicyAllWorkers marks the TFJob succeeded when all workers succeed,
+	// except the failed workers tolerated by the failure policy of the workers.
+	// It is ignored if there is a Chief or Master replica.
 	SuccessPolicyAllWorkers SuccessPolicy = "AllWorkers"
+	// SuccessPolicyChiefOnly lets only the chief decide the outcome of the TFJob.
+	// It succeeds as soon as the chief succeeds, even if other replicas are still
+	// running, and fails only if the chief fails: the failed replicas of other
+	// types or indexes never fail the TFJob, regardless of their failure policies.
+	// Worker 0 is the chief if there is no Chief or Master replica.
+	SuccessPolicyChiefOnly SuccessPolicy = "ChiefOnly"
+	// SuccessPolicyAnyWorker marks the TFJob succeeded as soon as any worker succeeds.
+	SuccessPolicyAnyWorker SuccessPolicy = "AnyWorker"
+	// SuccessPolicyWorkerThreshold marks the TFJob succeeded when the number of
+	// succeeded workers reaches the SuccessThreshold of the TFJob.
+	SuccessPolicyWorkerThreshold SuccessPolicy = "WorkerThreshold"
+)
+
+// JobSuspended means the TFJob is suspended and none of its replicas are running.
+const JobSuspended commonv1.JobConditionType = "Suspended"
+
+// JobWaiting means the replicas of the later stages of the InOrder startup
+// policy wait for the replicas of the earlier stages to start. It is False once
+// they have started.
+const JobWaiting commonv1.JobConditionType = "Waiting"
+
+// JobOOMKilled means a replica of the TFJob has run out of memory. Its message
+// tells the last replica which ran out of memory.
+const JobOOMKilled commonv1.JobConditionType = "OOMKilled"
+
+// JobPodGroupAdmitted means the PodGroup of a gang-scheduled TFJob is admitted
+// by the gang scheduler. It is False while the PodGroup waits to be admitted.
+const JobPodGroupAdmitted commonv1.JobConditionType = "PodGroupAdmitted"
+
+// FailurePolicyType is the type of a failure policy.
+type FailurePolicyType string
+
+const (
+	// FailurePolicyFailJob fails the TFJob as soon as a replica fails.
+	FailurePolicyFailJob FailurePolicyType = "FailJob"
+	// FailurePolicyIgnore never fails the TFJob because of failed replicas.
+	FailurePolicyIgnore FailurePolicyType = "Ignore"
+	// FailurePolicyTolerate fails the TFJob when more than MaxFailures replicas fail.
+	FailurePolicyTolerate FailurePolicyType = "Tolerate"
+)
+
+// FailurePolicy is the policy to decide whether failed replicas of a
+// replica type fail the TFJob.
+type FailurePolicy struct {
+	// Type is the type of the failure policy.
+	// One of "FailJob", "Ignore" or "Tolerate". Default to "FailJob".
+	// +optional
+	Type FailurePolicyType `json:"type,omitempty"`
+
+	// MaxFailures is the number of failed replicas tolerated by the "Tolerate" type.
+	// +optional
+	MaxFailures *int32 `json:"maxFailures,omitempty"`
+}
+
+// ClusterSpecMode is the way to address the replicas in the cluster spec of TF_CONFIG.
+type ClusterSpecMode string
+
+const (
+	// ClusterSpecModeService addresses the replicas by the DNS names of their
+	// Services. One Service is created per replica.
+	ClusterSpecModeService ClusterSpecMode = "Service"
+	// ClusterSpecModePodIP addresses the replicas by the IPs of their pods, or of
+	// their nodes for pods with hostNetwork. No Service is created. Since the
+	// addresses are only known after the pods are scheduled, TF_CONFIG is written
+	// to the file in the TF_CONFIG_FILE environment variable once all of them
+	// exist, and the TensorFlow container only starts then. The TF_CONFIG
+	// environment variable is not set, thus the training code has to set it
+	// from the file before TensorFlow reads it.
+	ClusterSpecModePodIP ClusterSpecMode = "PodIP"
+	// ClusterSpecModeHostname addresses the replicas by the hostnames of their pods
+	// in a single headless Service named after the TFJob, e.g.
+	// "<tfjob>-worker-0.<tfjob>.<namespace>.svc". Only one Service is created per TFJob.
+	ClusterSpecModeHostname ClusterSpecMode = "Hostname"
+)
+
+// TFConfigDelivery is the way to deliver TF_CONFIG to the replicas.
+type TFConfigDelivery string
+
+const (
+	// TFConfigDeliveryEnv sets the whole TF_CONFIG in the environment variable.
+	TFConfigDeliveryEnv TFConfigDelivery = "Env"
+	// TFConfigDeliveryConfigMap writes the cluster spec into a ConfigMap owned by
+	// the TFJob and mounted into the replicas, at the path in the
+	// TF_CLUSTER_SPEC_FILE environment variable. The ConfigMap is updated in
+	// place when the cluster spec changes, e.g. when the Worker replicas of a
+	// dynamic worker TFJob are scaled.
+	// TF_CONFIG only contains the task of the replica and an empty cluster, and
+	// TensorFlow does not read the file, thus the training code has to set the
+	// cluster of TF_CONFIG from the file before TensorFlow reads TF_CONFIG.
+	TFConfigDeliveryConfigMap TFConfigDelivery = "ConfigMap"
+)
+
+// ExitCodePolicy decides whether a replica which exited with an exit code is
+// restarted when the restart policy of the replica type is "ExitCode".
+// The exit codes in neither list are decided by the default rule, which treats
+// 1, 2, 126, 127, 128 and 139 as permanent and the others as retryable.
+type ExitCodePolicy struct {
+	// RetryableExitCodes are the exit codes which restart the replica.
+	// +optional
+	RetryableExitCodes []ExitCodeRange `json:"retryableExitCodes,omitempty"`
+
+	// PermanentExitCodes are the exit codes which fail the replica.
+	// They take precedence over RetryableExitCodes.
+	// +optional
+	PermanentExitCodes []ExitCodeRange `json:"permanentExitCodes,omitempty"`
+}
+
+// ExitCodeRange is an inclusive range of exit codes.
+type ExitCodeRange struct {
+	// Min is the first exit code of the range.
+	Min int32 `json:"min"`
+
+	// Max is the last exit code of the range. Default to Min.
+	// +optional
+	Max *int32 `json:"max,omitempty"`
+}
+
+// StartupPolicyType is the type of a startup policy.
+type StartupPolicyType string
+
+const (
+	// StartupPolicyParallel creates the replicas of all types at once.
+	StartupPolicyParallel StartupPolicyType = "Parallel"
+	// StartupPolicyInOrder creates the Worker and Evaluator replicas only after
+	// all PS, Chief, Master and Coordinator replicas have started.
+	StartupPolicyInOrder StartupPolicyType = "InOrder"
+)
+
+// StartupPolicy is the policy for the order in which the replicas of a TFJob
+// are created.
+type StartupPolicy struct {
+	// Type is the type of the startup policy.
+	// One of "Parallel" or "InOrder". Default to "Parallel".
+	// +optional
+	Type StartupPolicyType `json:"type,omitempty"`
+
+	// WaitForReady makes the "InOrder" type wait for the earlier replicas to be
+	// Ready, i.e. to pass their readiness probes, rather than just Running.
+	// Default to false.
+	// +optional
+	WaitForReady bool `json:"waitForReady,omitempty"`
+}
+
+// RestartScope is the scope of the replicas restarted when a replica fails
+// with a retryable exit code.
+type RestartScope string
+
+const (
+	// RestartScopeReplica restarts only the failed replica.
+	RestartScopeReplica RestartScope = "Replica"
+	// RestartScopeJob restarts all replicas of the TFJob as a new attempt,
+	// e.g. for synchronous strategies like MultiWorkerMirroredStrategy.
+	RestartScopeJob RestartScope = "Job"
+)
+
+// PendingTimeoutAction is the action taken when a replica stays Pending for too long.
+type PendingTimeoutAction string
+
+const (
+	// PendingTimeoutActionFail fails the TFJob.
+	PendingTimeoutActionFail PendingTimeoutAction = "Fail"
+	// PendingTimeoutActionSuspend suspends the TFJob like TFJobSpec.Suspend, but
+	// by its Suspended condition with the reason "TFJobPendingTimeout" instead of
+	// its spec, which the operator never changes. Update the spec, e.g. set
+	// spec.suspend to false, to resume the TFJob, which may be suspended again
+	// when its replicas stay Pending for too long again.
+	PendingTimeoutActionSuspend PendingTimeoutAction = "Suspend"
 )
+
+// PendingTimeoutPolicy is the policy for the replicas which stay Pending, e.g.
+// because they can not be scheduled or their images can not be pulled.
+type PendingTimeoutPolicy struct {
+	// TimeoutSeconds is how long a replica may stay Pending since it is created.
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+
+	// Action is the action taken when the timeout expires.
+	// One of "Fail" or "Suspend". Default to "Fail".
+	// +optional
+	Action PendingTimeoutAction `json:"action,omitempty"`
+}
+
+// MemoryEscalationPolicy is the policy for recreating the replicas whose
+// TensorFlow container is killed because it ran out of memory with more memory.
+type MemoryEscalationPolicy struct {
+	// FactorPercent is the percentage of the memory request and limit of the
+	// killed replica which the recreated replica gets, e.g. 150 raises them by half.
+	// It must be greater than 100.
+	FactorPercent int32 `json:"factorPercent"`
+
+	// MaxMemory is the ceiling of the raised memory request and limit.
+	MaxMemory resource.Quantity `json:"maxMemory"`
+}
+
+// ElasticPolicy is the policy for scaling the Worker replicas of a running TFJob.
+type ElasticPolicy struct {
+	// MinReplicas is the lower limit for the number of Worker replicas.
+	// Default to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of Worker replicas.
+	// If not set, the number of Worker replicas is not bounded above.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
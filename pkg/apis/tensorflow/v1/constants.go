@@ -31,4 +31,30 @@ const (
 	DefaultPort = 2222
 	// DefaultRestartPolicy is default RestartPolicy for TFReplicaSpec.
 	DefaultRestartPolicy = common.RestartPolicyNever
+
+	// EnvJobName is the environment variable of the TFJob name, which is set in
+	// all containers and init containers of the replicas as well as the ones below.
+	EnvJobName = "TFJOB_NAME"
+	// EnvJobNamespace is the environment variable of the TFJob namespace.
+	EnvJobNamespace = "TFJOB_NAMESPACE"
+	// EnvReplicaType is the environment variable of the replica type in lower case, e.g. "worker".
+	EnvReplicaType = "TFJOB_REPLICA_TYPE"
+	// EnvReplicaIndex is the environment variable of the replica index.
+	EnvReplicaIndex = "TFJOB_REPLICA_INDEX"
+	// EnvWorldSize is the environment variable of the number of replicas in the
+	// cluster spec, which excludes e.g. the Evaluator.
+	EnvWorldSize = "TFJOB_WORLD_SIZE"
+	// EnvReplicasFormat is the format of the environment variable of the number of
+	// replicas of a type in upper case, e.g. "TFJOB_WORKER_REPLICAS".
+	EnvReplicasFormat = "TFJOB_%s_REPLICAS"
+
+	// ClusterDomainAnnotation is the annotation of the TFJob to override the
+	// cluster domain of the operator in the cluster spec, such as "cluster.local".
+	ClusterDomainAnnotation = "kubeflow.org/cluster-domain"
+	// ContainerNameAnnotation is the annotation of the pod template of a replica
+	// type to override the name of the TensorFlow container, such as "trainer".
+	ContainerNameAnnotation = "kubeflow.org/container-name"
+	// PortNameAnnotation is the annotation of the pod template of a replica type
+	// to override the name of the port of the TensorFlow container.
+	PortNameAnnotation = "kubeflow.org/port-name"
 )
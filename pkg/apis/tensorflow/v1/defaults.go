@@ -15,6 +15,7 @@
 package v1
 
 import (
+	"reflect"
 	"strings"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
@@ -32,11 +33,12 @@ func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
 
-// setDefaultPort sets the default ports for tensorflow container.
-func setDefaultPort(spec *v1.PodSpec) {
+// setDefaultPort sets the default ports for tensorflow container, which are
+// named containerName and portName.
+func setDefaultPort(spec *v1.PodSpec, containerName, portName string) {
 	index := 0
 	for i, container := range spec.Containers {
-		if container.Name == DefaultContainerName {
+		if container.Name == containerName {
 			index = i
 			break
 		}
@@ -44,14 +46,14 @@ func setDefaultPort(spec *v1.PodSpec) {
 
 	hasTFJobPort := false
 	for _, port := range spec.Containers[index].Ports {
-		if port.Name == DefaultPortName {
+		if port.Name == portName {
 			hasTFJobPort = true
 			break
 		}
 	}
 	if !hasTFJobPort {
 		spec.Containers[index].Ports = append(spec.Containers[index].Ports, v1.ContainerPort{
-			Name:          DefaultPortName,
+			Name:          portName,
 			ContainerPort: DefaultPort,
 		})
 	}
@@ -66,13 +68,37 @@ func setDefaultReplicas(spec *commonv1.ReplicaSpec) {
 	}
 }
 
+// setDefaultElasticPolicy sets the default lower limit of the Worker replicas
+// and starts the Worker replicas from it if they are not specified.
+func setDefaultElasticPolicy(tfJob *TFJob) {
+	policy := tfJob.Spec.ElasticPolicy
+	if policy == nil {
+		return
+	}
+	if policy.MinReplicas == nil {
+		policy.MinReplicas = Int32(1)
+	}
+	if spec, ok := tfJob.Spec.TFReplicaSpecs[TFReplicaTypeWorker]; ok && spec != nil && spec.Replicas == nil {
+		spec.Replicas = Int32(*policy.MinReplicas)
+	}
+}
+
 // setTypeNamesToCamelCase sets the name of all replica types from any case to correct case.
 func setTypeNamesToCamelCase(tfJob *TFJob) {
-	setTypeNameToCamelCase(tfJob, TFReplicaTypePS)
-	setTypeNameToCamelCase(tfJob, TFReplicaTypeWorker)
-	setTypeNameToCamelCase(tfJob, TFReplicaTypeChief)
-	setTypeNameToCamelCase(tfJob, TFReplicaTypeMaster)
-	setTypeNameToCamelCase(tfJob, TFReplicaTypeEval)
+	for _, typ := range []commonv1.ReplicaType{
+		TFReplicaTypePS,
+		TFReplicaTypeWorker,
+		TFReplicaTypeChief,
+		TFReplicaTypeMaster,
+		TFReplicaTypeEval,
+		TFReplicaTypeCoordinator,
+	} {
+		setTypeNameToCamelCase(tfJob, typ)
+		setMapTypeNameToCamelCase(tfJob.Spec.FailurePolicies, typ)
+		setMapTypeNameToCamelCase(tfJob.Spec.ExitCodePolicies, typ)
+		setMapTypeNameToCamelCase(tfJob.Spec.ClusterSpecMembership, typ)
+		setMapTypeNameToCamelCase(tfJob.Spec.MinMembers, typ)
+	}
 }
 
 // setTypeNameToCamelCase sets the name of the replica type from any case to correct case.
@@ -88,6 +114,31 @@ func setTypeNameToCamelCase(tfJob *TFJob, typ commonv1.ReplicaType) {
 	}
 }
 
+// setMapTypeNameToCamelCase sets the name of the replica type in the keys of
+// a map keyed by replica type, e.g. the failure policies, to camel case, in the
+// same way as the TFReplicaSpecs.
+func setMapTypeNameToCamelCase(m interface{}, typ commonv1.ReplicaType) {
+	v := reflect.ValueOf(m)
+	for _, key := range v.MapKeys() {
+		t := key.String()
+		if strings.EqualFold(t, string(typ)) && t != string(typ) {
+			value := v.MapIndex(key)
+			v.SetMapIndex(key, reflect.Value{})
+			v.SetMapIndex(reflect.ValueOf(typ), value)
+			return
+		}
+	}
+}
+
+// setDefaultFailurePolicies sets the default type of the failure policies to FailJob.
+func setDefaultFailurePolicies(tfJob *TFJob) {
+	for _, policy := range tfJob.Spec.FailurePolicies {
+		if policy != nil && policy.Type == "" {
+			policy.Type = FailurePolicyFailJob
+		}
+	}
+}
+
 // SetDefaults_TFJob sets any unspecified values to defaults.
 func SetDefaults_TFJob(tfjob *TFJob) {
 	// Set default cleanpod policy to Running.
@@ -104,10 +155,18 @@ func SetDefaults_TFJob(tfjob *TFJob) {
 	// Update the key of TFReplicaSpecs to camel case.
 	setTypeNamesToCamelCase(tfjob)
 
+	// Set default elastic policy and initial worker replicas.
+	setDefaultElasticPolicy(tfjob)
+
+	// Set default type of the failure policies.
+	setDefaultFailurePolicies(tfjob)
+
 	for _, spec := range tfjob.Spec.TFReplicaSpecs {
 		// Set default replicas to 1.
 		setDefaultReplicas(spec)
 		// Set default port to tensorFlow container.
-		setDefaultPort(&spec.Template.Spec)
+		setDefaultPort(&spec.Template.Spec,
+			GetContainerName(&tfjob.Spec, spec.Template.Annotations),
+			GetPortName(&tfjob.Spec, spec.Template.Annotations))
 	}
 }
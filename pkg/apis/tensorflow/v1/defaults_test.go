@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	"github.com/kubeflow/common/pkg/util"
@@ -119,6 +120,29 @@ func TestSetTypeNames(t *testing.T) {
 	}
 }
 
+func TestSetDefaultFailurePolicies(t *testing.T) {
+	evalLowerCase := commonv1.ReplicaType("evaluator")
+	tfJob := &TFJob{
+		Spec: TFJobSpec{
+			FailurePolicies: map[commonv1.ReplicaType]*FailurePolicy{
+				evalLowerCase:       &FailurePolicy{Type: FailurePolicyIgnore},
+				TFReplicaTypeWorker: &FailurePolicy{},
+			},
+		},
+	}
+
+	SetDefaults_TFJob(tfJob)
+	if _, ok := tfJob.Spec.FailurePolicies[evalLowerCase]; ok {
+		t.Errorf("Failed to delete key %s", evalLowerCase)
+	}
+	if policy, ok := tfJob.Spec.FailurePolicies[TFReplicaTypeEval]; !ok || policy.Type != FailurePolicyIgnore {
+		t.Errorf("Failed to set key %s", TFReplicaTypeEval)
+	}
+	if policy := tfJob.Spec.FailurePolicies[TFReplicaTypeWorker]; policy.Type != FailurePolicyFailJob {
+		t.Errorf("Want failure policy type %s; Got %s", FailurePolicyFailJob, policy.Type)
+	}
+}
+
 func TestSetDefaultTFJob(t *testing.T) {
 	customPortName := "customPort"
 	var customPort int32 = 1234
@@ -277,7 +301,125 @@ func TestSetDefaultTFJob(t *testing.T) {
 	}
 }
 
+func TestSetDefaultElasticPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		policy           *ElasticPolicy
+		replicas         *int32
+		expectedMin      *int32
+		expectedReplicas *int32
+	}{
+		"no elastic policy": {
+			policy:           nil,
+			replicas:         nil,
+			expectedMin:      nil,
+			expectedReplicas: Int32(1),
+		},
+		"default min replicas": {
+			policy:           &ElasticPolicy{MaxReplicas: Int32(4)},
+			replicas:         nil,
+			expectedMin:      Int32(1),
+			expectedReplicas: Int32(1),
+		},
+		"start from min replicas": {
+			policy:           &ElasticPolicy{MinReplicas: Int32(2), MaxReplicas: Int32(4)},
+			replicas:         nil,
+			expectedMin:      Int32(2),
+			expectedReplicas: Int32(2),
+		},
+		"keep specified replicas": {
+			policy:           &ElasticPolicy{MinReplicas: Int32(2), MaxReplicas: Int32(4)},
+			replicas:         Int32(3),
+			expectedMin:      Int32(2),
+			expectedReplicas: Int32(3),
+		},
+	}
+
+	for name, tc := range testCases {
+		tfJob := &TFJob{
+			Spec: TFJobSpec{
+				ElasticPolicy: tc.policy,
+				TFReplicaSpecs: map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+					TFReplicaTypeWorker: &commonv1.ReplicaSpec{
+						Replicas: tc.replicas,
+						Template: v1.PodTemplateSpec{
+							Spec: v1.PodSpec{
+								Containers: []v1.Container{
+									v1.Container{
+										Name:  DefaultContainerName,
+										Image: testImage,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		SetDefaults_TFJob(tfJob)
+		if tc.policy != nil && !reflect.DeepEqual(tfJob.Spec.ElasticPolicy.MinReplicas, tc.expectedMin) {
+			t.Errorf("%s: Want min replicas %v; Got %v", name, *tc.expectedMin, *tfJob.Spec.ElasticPolicy.MinReplicas)
+		}
+		if actual := tfJob.Spec.TFReplicaSpecs[TFReplicaTypeWorker].Replicas; !reflect.DeepEqual(actual, tc.expectedReplicas) {
+			t.Errorf("%s: Want replicas %v; Got %v", name, *tc.expectedReplicas, *actual)
+		}
+	}
+}
+
 func cleanPodPolicyPointer(cleanPodPolicy commonv1.CleanPodPolicy) *commonv1.CleanPodPolicy {
 	c := cleanPodPolicy
 	return &c
 }
+
+func TestSetDefaultPortWithCustomNames(t *testing.T) {
+	containerName := "trainer"
+	portName := "trainer-port"
+	defaultContainerName := DefaultContainerName
+	defaultPortName := DefaultPortName
+	testCases := map[string]struct {
+		spec        TFJobSpec
+		annotations map[string]string
+	}{
+		"names of the tfjob": {
+			spec: TFJobSpec{ContainerName: &containerName, PortName: &portName},
+		},
+		"names of the pod template": {
+			annotations: map[string]string{
+				ContainerNameAnnotation: containerName,
+				PortNameAnnotation:      portName,
+			},
+		},
+		"pod template takes precedence": {
+			spec: TFJobSpec{ContainerName: &defaultContainerName, PortName: &defaultPortName},
+			annotations: map[string]string{
+				ContainerNameAnnotation: containerName,
+				PortNameAnnotation:      portName,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tfJob := &TFJob{Spec: tc.spec}
+		tfJob.Spec.TFReplicaSpecs = map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+			TFReplicaTypeWorker: {
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{
+							{Name: "sidecar", Image: testImage},
+							{Name: containerName, Image: testImage},
+						},
+					},
+				},
+			},
+		}
+		SetDefaults_TFJob(tfJob)
+		containers := tfJob.Spec.TFReplicaSpecs[TFReplicaTypeWorker].Template.Spec.Containers
+		if len(containers[0].Ports) != 0 {
+			t.Errorf("%s: expected no port in the sidecar, got %v", name, containers[0].Ports)
+		}
+		expected := []v1.ContainerPort{{Name: portName, ContainerPort: DefaultPort}}
+		if !reflect.DeepEqual(containers[1].Ports, expected) {
+			t.Errorf("%s: expected ports %v, got %v", name, expected, containers[1].Ports)
+		}
+	}
+}
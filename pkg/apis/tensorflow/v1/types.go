@@ -16,7 +16,10 @@ package v1
 
 import (
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +genclient
@@ -40,7 +43,7 @@ type TFJob struct {
 	// Populated by the system.
 	// Read-only.
 	// +optional
-	Status commonv1.JobStatus `json:"status,omitempty"`
+	Status TFJobStatus `json:"status,omitempty"`
 }
 
 // TFJobSpec is a desired state description of the TFJob.
@@ -55,6 +58,12 @@ type TFJobSpec struct {
 	// +optional
 	SuccessPolicy *SuccessPolicy `json:"successPolicy,omitempty"`
 
+	// SuccessThreshold is the number or percentage of Worker replicas which need
+	// to succeed to mark the TFJob as succeeded. A percentage is rounded up.
+	// Only used with the "WorkerThreshold" success policy.
+	// +optional
+	SuccessThreshold *intstr.IntOrString `json:"successThreshold,omitempty"`
+
 	// A map of TFReplicaType (type) to ReplicaSpec (value). Specifies the TF cluster configuration.
 	// For example,
 	//   {
@@ -63,8 +72,230 @@ type TFJobSpec struct {
 	//   }
 	TFReplicaSpecs map[commonv1.ReplicaType]*commonv1.ReplicaSpec `json:"tfReplicaSpecs"`
 
+	// A map of TFReplicaType (type) to FailurePolicy (value). Specifies whether
+	// failed replicas of the type fail the TFJob, e.g.
+	//   {
+	//     "Evaluator": {"type": "Ignore"},
+	//     "Worker": {"type": "Tolerate", "maxFailures": 2},
+	//   }
+	// The TFJob fails as soon as a replica fails for the types not in the map.
+	// +optional
+	FailurePolicies map[commonv1.ReplicaType]*FailurePolicy `json:"failurePolicies,omitempty"`
+
+	// A map of TFReplicaType (type) to ExitCodePolicy (value). Specifies which exit
+	// codes restart the replicas of the type with the "ExitCode" restart policy, e.g.
+	//   {
+	//     "Worker": {
+	//       "retryableExitCodes": [{"min": 130, "max": 143}],
+	//       "permanentExitCodes": [{"min": 3}],
+	//     },
+	//   }
+	// +optional
+	ExitCodePolicies map[commonv1.ReplicaType]*ExitCodePolicy `json:"exitCodePolicies,omitempty"`
+
+	// A map of TFReplicaType (type) to whether the replicas of the type are members
+	// of the cluster spec in TF_CONFIG. By default all replica types except Evaluator
+	// are members. Replicas which are not members still receive TF_CONFIG with their
+	// own task, e.g.
+	//   {
+	//     "Evaluator": true,
+	//   }
+	// +optional
+	ClusterSpecMembership map[commonv1.ReplicaType]bool `json:"clusterSpecMembership,omitempty"`
+
+	// ClusterSpecMode specifies how the replicas are addressed in TF_CONFIG.
+	// One of "Service", "PodIP" or "Hostname". Default to "Service".
+	// +optional
+	ClusterSpecMode *ClusterSpecMode `json:"clusterSpecMode,omitempty"`
+
+	// TFConfigDelivery specifies how TF_CONFIG is delivered to the replicas.
+	// One of "Env" or "ConfigMap". Default to "Env". With "ConfigMap", the
+	// training code has to merge the cluster spec from the file in
+	// TF_CLUSTER_SPEC_FILE into TF_CONFIG itself.
+	// +optional
+	TFConfigDelivery *TFConfigDelivery `json:"tfConfigDelivery,omitempty"`
+
+	// ContainerName is the name of the TensorFlow container of the replicas, which
+	// receives TF_CONFIG and decides the exit code of the replicas. The
+	// "kubeflow.org/container-name" annotation of the pod template of a replica
+	// type takes precedence over it. Default to "tensorflow".
+	// +optional
+	ContainerName *string `json:"containerName,omitempty"`
+
+	// PortName is the name of the port of the TensorFlow container which is used
+	// in the cluster spec. The "kubeflow.org/port-name" annotation of the pod
+	// template of a replica type takes precedence over it. Default to "tfjob-port".
+	// +optional
+	PortName *string `json:"portName,omitempty"`
+
 	// A switch to enable dynamic worker
 	EnableDynamicWorker bool `json:"enableDynamicWorker,omitempty"`
+
+	// Suspend specifies whether the TFJob is suspended. Suspending a TFJob deletes
+	// all of its pods and services, and resuming it recreates the replicas from
+	// scratch. The ActiveDeadlineSeconds timer is paused while the TFJob is suspended.
+	// Default to false.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// ElasticPolicy lets the number of Worker replicas change within a range
+	// while the TFJob is running, e.g. through the scale subresource.
+	// It requires EnableDynamicWorker.
+	// +optional
+	ElasticPolicy *ElasticPolicy `json:"elasticPolicy,omitempty"`
+
+	// StartupPolicy specifies the order in which the replicas are created, e.g.
+	// to create the Worker replicas once the PS replicas are running.
+	// Default to create all replicas at once.
+	// +optional
+	StartupPolicy *StartupPolicy `json:"startupPolicy,omitempty"`
+
+	// PendingTimeoutPolicy fails or suspends the TFJob when any of its replicas
+	// stays Pending for too long. The reason of the TFJob quotes the reason why
+	// the replica is pending, e.g. "ImagePullBackOff".
+	// +optional
+	PendingTimeoutPolicy *PendingTimeoutPolicy `json:"pendingTimeoutPolicy,omitempty"`
+
+	// MemoryEscalationPolicy recreates the replicas which are OOMKilled with
+	// more memory. The raised memory is recorded in the ReplicaIndexStatuses.
+	// Default to keep the memory of the replicas.
+	// +optional
+	MemoryEscalationPolicy *MemoryEscalationPolicy `json:"memoryEscalationPolicy,omitempty"`
+
+	// RestartScope specifies which replicas are restarted when a replica with
+	// the ExitCode restart policy fails with a retryable exit code.
+	// One of "Replica" or "Job". Default to "Replica".
+	// +optional
+	RestartScope *RestartScope `json:"restartScope,omitempty"`
+
+	// A map of TFReplicaType (type) to the minimum number of replicas of the type
+	// which are gang-scheduled together, e.g. all PS and the Chief but only 2 of
+	// the Workers:
+	//   {
+	//     "Worker": 2,
+	//   }
+	// All replicas of the types not in the map are members, except the Workers
+	// of an elastic TFJob, of which the MinReplicas of the ElasticPolicy are
+	// members. The PS, Chief, Master and Coordinator replicas are never split.
+	// It cannot be used together with SchedulingPolicy.MinAvailable, and the
+	// TFJob fails if the gang scheduler of the operator does not support it,
+	// e.g. the coscheduling plugin.
+	// +optional
+	MinMembers map[commonv1.ReplicaType]int32 `json:"minMembers,omitempty"`
+}
+
+// TFJobStatus represents the current observed state of the TFJob.
+type TFJobStatus struct {
+	commonv1.JobStatus `json:",inline"`
+
+	// ReplicaIndexStatuses is the status of each replica index, keyed by the
+	// replica type, e.g. {"Worker": [{"index": 0, "podName": "foo-worker-0", "restarts": 2}]}.
+	// +optional
+	ReplicaIndexStatuses map[commonv1.ReplicaType][]ReplicaIndexStatus `json:"replicaIndexStatuses,omitempty"`
+
+	// RestartAttempt is the number of times all replicas of the TFJob have been
+	// restarted with the "Job" restart scope. The pods of the current attempt
+	// are labeled with it.
+	// +optional
+	RestartAttempt int32 `json:"restartAttempt,omitempty"`
+
+	// WaitingFor is the replica types which have not started yet, while the
+	// replicas of the later stages of the InOrder startup policy wait for them.
+	// +optional
+	WaitingFor []commonv1.ReplicaType `json:"waitingFor,omitempty"`
+
+	// PodGroupStatus is the status of the PodGroup of the TFJob, which is set
+	// when the TFJob is gang-scheduled.
+	// +optional
+	PodGroupStatus *PodGroupStatus `json:"podGroupStatus,omitempty"`
+
+	// Selector is the label selector of the Worker pods of the TFJob, which is
+	// the selector of the scale subresource, e.g. for the HorizontalPodAutoscaler.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// SuspendedGeneration is the generation of the TFJob when it is suspended
+	// by its PendingTimeoutPolicy. The TFJob is resumed once its spec is updated
+	// afterwards, e.g. when spec.suspend is set to false.
+	// +optional
+	SuspendedGeneration int64 `json:"suspendedGeneration,omitempty"`
+}
+
+// PodGroupStatus represents the current observed state of the PodGroup of a TFJob.
+type PodGroupStatus struct {
+	// Name is the name of the PodGroup.
+	Name string `json:"name"`
+
+	// Admitted is true once the PodGroup is admitted by the gang scheduler, thus
+	// the replicas of the TFJob can be scheduled together.
+	Admitted bool `json:"admitted"`
+
+	// Message tells why the PodGroup is not admitted yet, if the gang scheduler
+	// reports it.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReplicaIndexStatus represents the current observed state of a replica index.
+type ReplicaIndexStatus struct {
+	// Index is the index of the replica.
+	Index int32 `json:"index"`
+
+	// Restarts is the number of times the replica has been restarted by the operator.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+
+	// LastRestartTime is the last time the replica was restarted by the operator.
+	// +optional
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
+
+	// Disruptions is the number of times the replica has been recreated because
+	// its pod was evicted, preempted or lost with its node. They are not counted
+	// in Restarts.
+	// +optional
+	Disruptions int32 `json:"disruptions,omitempty"`
+
+	// PodName is the name of the current pod of the replica.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// Phase is the phase of the current pod of the replica.
+	// +optional
+	Phase v1.PodPhase `json:"phase,omitempty"`
+
+	// NodeName is the node the current pod of the replica is scheduled to.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// ContainerRestarts is the number of times the TensorFlow container of the
+	// current pod has been restarted by the kubelet.
+	// +optional
+	ContainerRestarts int32 `json:"containerRestarts,omitempty"`
+
+	// LastExitCode is the exit code of the last termination of the TensorFlow
+	// container of the current pod.
+	// +optional
+	LastExitCode *int32 `json:"lastExitCode,omitempty"`
+
+	// TerminationReason is the reason of the last termination of the TensorFlow
+	// container of the current pod, e.g. "OOMKilled".
+	// +optional
+	TerminationReason string `json:"terminationReason,omitempty"`
+
+	// WaitingReason is the reason why the current pod of the replica is not
+	// running, e.g. "ImagePullBackOff" or "Unschedulable".
+	// +optional
+	WaitingReason string `json:"waitingReason,omitempty"`
+
+	// MemoryRequest is the memory request of the TensorFlow container of the
+	// replica raised by the MemoryEscalationPolicy.
+	// +optional
+	MemoryRequest *resource.Quantity `json:"memoryRequest,omitempty"`
+
+	// MemoryLimit is the memory limit of the TensorFlow container of the
+	// replica raised by the MemoryEscalationPolicy.
+	// +optional
+	MemoryLimit *resource.Quantity `json:"memoryLimit,omitempty"`
 }
 
 // TFReplicaType is the type for TFReplica. Can be one of: "Chief"/"Master" (semantically equivalent),
@@ -89,6 +320,12 @@ const (
 
 	// TFReplicaTypeEval is the type for evaluation replica in TensorFlow.
 	TFReplicaTypeEval commonv1.ReplicaType = "Evaluator"
+
+	// TFReplicaTypeCoordinator is the type for the coordinator of
+	// tf.distribute.experimental.ParameterServerStrategy. It is the "chief" task in
+	// TF_CONFIG. The TFJob completes when the coordinator exits, and the running
+	// Worker and PS replicas are torn down according to the CleanPodPolicy.
+	TFReplicaTypeCoordinator commonv1.ReplicaType = "Coordinator"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
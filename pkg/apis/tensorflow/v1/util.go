@@ -23,6 +23,11 @@ func IsChieforMaster(typ commonv1.ReplicaType) bool {
 	return typ == TFReplicaTypeChief || typ == TFReplicaTypeMaster
 }
 
+// IsCoordinator returns true if the type is Coordinator.
+func IsCoordinator(typ commonv1.ReplicaType) bool {
+	return typ == TFReplicaTypeCoordinator
+}
+
 // IsWorker returns true if the type is Worker.
 func IsWorker(typ commonv1.ReplicaType) bool {
 	return typ == TFReplicaTypeWorker
@@ -32,3 +37,27 @@ func IsWorker(typ commonv1.ReplicaType) bool {
 func IsEvaluator(typ commonv1.ReplicaType) bool {
 	return typ == TFReplicaTypeEval
 }
+
+// GetContainerName returns the name of the TensorFlow container of the replicas
+// with the annotations, i.e. the annotations of their pod template or pods.
+func GetContainerName(spec *TFJobSpec, annotations map[string]string) string {
+	if name := annotations[ContainerNameAnnotation]; name != "" {
+		return name
+	}
+	if spec.ContainerName != nil && *spec.ContainerName != "" {
+		return *spec.ContainerName
+	}
+	return DefaultContainerName
+}
+
+// GetPortName returns the name of the port of the TensorFlow container of the
+// replicas with the annotations, i.e. the annotations of their pod template or pods.
+func GetPortName(spec *TFJobSpec, annotations map[string]string) string {
+	if name := annotations[PortNameAnnotation]; name != "" {
+		return name
+	}
+	if spec.PortName != nil && *spec.PortName != "" {
+		return *spec.PortName
+	}
+	return DefaultPortName
+}
@@ -21,8 +21,207 @@ package v1
 import (
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticPolicy) DeepCopyInto(out *ElasticPolicy) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticPolicy.
+func (in *ElasticPolicy) DeepCopy() *ElasticPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExitCodePolicy) DeepCopyInto(out *ExitCodePolicy) {
+	*out = *in
+	if in.RetryableExitCodes != nil {
+		in, out := &in.RetryableExitCodes, &out.RetryableExitCodes
+		*out = make([]ExitCodeRange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PermanentExitCodes != nil {
+		in, out := &in.PermanentExitCodes, &out.PermanentExitCodes
+		*out = make([]ExitCodeRange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExitCodePolicy.
+func (in *ExitCodePolicy) DeepCopy() *ExitCodePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExitCodePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExitCodeRange) DeepCopyInto(out *ExitCodeRange) {
+	*out = *in
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExitCodeRange.
+func (in *ExitCodeRange) DeepCopy() *ExitCodeRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ExitCodeRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailurePolicy) DeepCopyInto(out *FailurePolicy) {
+	*out = *in
+	if in.MaxFailures != nil {
+		in, out := &in.MaxFailures, &out.MaxFailures
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicy.
+func (in *FailurePolicy) DeepCopy() *FailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryEscalationPolicy) DeepCopyInto(out *MemoryEscalationPolicy) {
+	*out = *in
+	out.MaxMemory = in.MaxMemory.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryEscalationPolicy.
+func (in *MemoryEscalationPolicy) DeepCopy() *MemoryEscalationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryEscalationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingTimeoutPolicy) DeepCopyInto(out *PendingTimeoutPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingTimeoutPolicy.
+func (in *PendingTimeoutPolicy) DeepCopy() *PendingTimeoutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingTimeoutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodGroupStatus) DeepCopyInto(out *PodGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGroupStatus.
+func (in *PodGroupStatus) DeepCopy() *PodGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaIndexStatus) DeepCopyInto(out *ReplicaIndexStatus) {
+	*out = *in
+	if in.LastRestartTime != nil {
+		in, out := &in.LastRestartTime, &out.LastRestartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastExitCode != nil {
+		in, out := &in.LastExitCode, &out.LastExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryRequest != nil {
+		in, out := &in.MemoryRequest, &out.MemoryRequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaIndexStatus.
+func (in *ReplicaIndexStatus) DeepCopy() *ReplicaIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartupPolicy) DeepCopyInto(out *StartupPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartupPolicy.
+func (in *StartupPolicy) DeepCopy() *StartupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StartupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TFJob) DeepCopyInto(out *TFJob) {
 	*out = *in
@@ -93,6 +292,11 @@ func (in *TFJobSpec) DeepCopyInto(out *TFJobSpec) {
 		*out = new(SuccessPolicy)
 		**out = **in
 	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	if in.TFReplicaSpecs != nil {
 		in, out := &in.TFReplicaSpecs, &out.TFReplicaSpecs
 		*out = make(map[commonv1.ReplicaType]*commonv1.ReplicaSpec, len(*in))
@@ -108,6 +312,100 @@ func (in *TFJobSpec) DeepCopyInto(out *TFJobSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.FailurePolicies != nil {
+		in, out := &in.FailurePolicies, &out.FailurePolicies
+		*out = make(map[commonv1.ReplicaType]*FailurePolicy, len(*in))
+		for key, val := range *in {
+			var outVal *FailurePolicy
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(FailurePolicy)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ExitCodePolicies != nil {
+		in, out := &in.ExitCodePolicies, &out.ExitCodePolicies
+		*out = make(map[commonv1.ReplicaType]*ExitCodePolicy, len(*in))
+		for key, val := range *in {
+			var outVal *ExitCodePolicy
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(ExitCodePolicy)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ClusterSpecMembership != nil {
+		in, out := &in.ClusterSpecMembership, &out.ClusterSpecMembership
+		*out = make(map[commonv1.ReplicaType]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClusterSpecMode != nil {
+		in, out := &in.ClusterSpecMode, &out.ClusterSpecMode
+		*out = new(ClusterSpecMode)
+		**out = **in
+	}
+	if in.TFConfigDelivery != nil {
+		in, out := &in.TFConfigDelivery, &out.TFConfigDelivery
+		*out = new(TFConfigDelivery)
+		**out = **in
+	}
+	if in.ContainerName != nil {
+		in, out := &in.ContainerName, &out.ContainerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PortName != nil {
+		in, out := &in.PortName, &out.PortName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ElasticPolicy != nil {
+		in, out := &in.ElasticPolicy, &out.ElasticPolicy
+		*out = new(ElasticPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupPolicy != nil {
+		in, out := &in.StartupPolicy, &out.StartupPolicy
+		*out = new(StartupPolicy)
+		**out = **in
+	}
+	if in.PendingTimeoutPolicy != nil {
+		in, out := &in.PendingTimeoutPolicy, &out.PendingTimeoutPolicy
+		*out = new(PendingTimeoutPolicy)
+		**out = **in
+	}
+	if in.MemoryEscalationPolicy != nil {
+		in, out := &in.MemoryEscalationPolicy, &out.MemoryEscalationPolicy
+		*out = new(MemoryEscalationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartScope != nil {
+		in, out := &in.RestartScope, &out.RestartScope
+		*out = new(RestartScope)
+		**out = **in
+	}
+	if in.MinMembers != nil {
+		in, out := &in.MinMembers, &out.MinMembers
+		*out = make(map[commonv1.ReplicaType]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -120,3 +418,47 @@ func (in *TFJobSpec) DeepCopy() *TFJobSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TFJobStatus) DeepCopyInto(out *TFJobStatus) {
+	*out = *in
+	in.JobStatus.DeepCopyInto(&out.JobStatus)
+	if in.ReplicaIndexStatuses != nil {
+		in, out := &in.ReplicaIndexStatuses, &out.ReplicaIndexStatuses
+		*out = make(map[commonv1.ReplicaType][]ReplicaIndexStatus, len(*in))
+		for key, val := range *in {
+			var outVal []ReplicaIndexStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]ReplicaIndexStatus, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.WaitingFor != nil {
+		in, out := &in.WaitingFor, &out.WaitingFor
+		*out = make([]commonv1.ReplicaType, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodGroupStatus != nil {
+		in, out := &in.PodGroupStatus, &out.PodGroupStatus
+		*out = new(PodGroupStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TFJobStatus.
+func (in *TFJobStatus) DeepCopy() *TFJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TFJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
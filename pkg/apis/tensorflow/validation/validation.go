@@ -16,51 +16,702 @@ package validation
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 )
 
+var (
+	validReplicaTypes = []commonv1.ReplicaType{
+		tfv1.TFReplicaTypePS,
+		tfv1.TFReplicaTypeWorker,
+		tfv1.TFReplicaTypeChief,
+		tfv1.TFReplicaTypeMaster,
+		tfv1.TFReplicaTypeEval,
+		tfv1.TFReplicaTypeCoordinator,
+	}
+	validRestartPolicies = []string{
+		string(commonv1.RestartPolicyAlways),
+		string(commonv1.RestartPolicyOnFailure),
+		string(commonv1.RestartPolicyNever),
+		string(commonv1.RestartPolicyExitCode),
+	}
+	validFailurePolicyTypes = []string{
+		string(tfv1.FailurePolicyFailJob),
+		string(tfv1.FailurePolicyIgnore),
+		string(tfv1.FailurePolicyTolerate),
+	}
+	validCleanPodPolicies = []string{
+		string(commonv1.CleanPodPolicyAll),
+		string(commonv1.CleanPodPolicyRunning),
+		string(commonv1.CleanPodPolicyNone),
+	}
+	validSuccessPolicies = []string{
+		string(tfv1.SuccessPolicyDefault),
+		string(tfv1.SuccessPolicyAllWorkers),
+		string(tfv1.SuccessPolicyChiefOnly),
+		string(tfv1.SuccessPolicyAnyWorker),
+		string(tfv1.SuccessPolicyWorkerThreshold),
+	}
+	validClusterSpecModes = []string{
+		string(tfv1.ClusterSpecModeService),
+		string(tfv1.ClusterSpecModePodIP),
+		string(tfv1.ClusterSpecModeHostname),
+	}
+	validStartupPolicyTypes = []string{
+		string(tfv1.StartupPolicyParallel),
+		string(tfv1.StartupPolicyInOrder),
+	}
+	validRestartScopes = []string{
+		string(tfv1.RestartScopeReplica),
+		string(tfv1.RestartScopeJob),
+	}
+	validPendingTimeoutActions = []string{
+		string(tfv1.PendingTimeoutActionFail),
+		string(tfv1.PendingTimeoutActionSuspend),
+	}
+	validTFConfigDeliveries = []string{
+		string(tfv1.TFConfigDeliveryEnv),
+		string(tfv1.TFConfigDeliveryConfigMap),
+	}
+)
+
 // ValidateV1TFJobSpec checks that the v1.TFJobSpec is valid.
 func ValidateV1TFJobSpec(c *tfv1.TFJobSpec) error {
-	return validateV1ReplicaSpecs(c.TFReplicaSpecs)
+	if errs := validateV1TFJobSpec(c, field.NewPath("spec")); len(errs) != 0 {
+		msg := fmt.Sprintf("TFJobSpec is not valid: %v", errs.ToAggregate())
+		log.Error(msg)
+		return fmt.Errorf(msg)
+	}
+	return nil
+}
+
+// ValidateV1TFJobAddresses checks that the addresses of the replicas of the
+// v1.TFJob in its cluster spec are valid. Unlike ValidateV1TFJobSpec, it needs
+// the name of the TFJob, which is the prefix of the hostnames of the replicas,
+// and its cluster domain annotation, which is their suffix.
+func ValidateV1TFJobAddresses(tfJob *tfv1.TFJob) error {
+	if errs := validateV1Addresses(tfJob); len(errs) != 0 {
+		msg := fmt.Sprintf("TFJob is not valid: %v", errs.ToAggregate())
+		log.Error(msg)
+		return fmt.Errorf(msg)
+	}
+	return nil
 }
 
-func validateV1ReplicaSpecs(specs map[commonv1.ReplicaType]*commonv1.ReplicaSpec) error {
+// ValidateV1TFJob checks that the v1.TFJob is valid on creation. Besides the
+// checks of ValidateV1TFJobSpec, which the stored TFJobs pass as well, it
+// checks the rules of the admission webhook, see validateV1AdmissionRules.
+func ValidateV1TFJob(tfJob *tfv1.TFJob) field.ErrorList {
+	allErrs := validateV1Addresses(tfJob)
+	allErrs = append(allErrs, validateV1TFJobSpec(&tfJob.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateV1AdmissionRules(tfJob)...)
+	return allErrs
+}
+
+// ValidateV1TFJobUpdate checks that the update from oldTFJob to tfJob is valid.
+// The rules of the admission webhook are only checked if oldTFJob passes them,
+// so that the TFJobs stored before the webhook can still be updated.
+func ValidateV1TFJobUpdate(tfJob, oldTFJob *tfv1.TFJob) field.ErrorList {
+	allErrs := validateV1Addresses(tfJob)
+	allErrs = append(allErrs, validateV1TFJobSpec(&tfJob.Spec, field.NewPath("spec"))...)
+	if len(validateV1AdmissionRules(oldTFJob)) == 0 {
+		allErrs = append(allErrs, validateV1AdmissionRules(tfJob)...)
+	}
+
+	// The replica types decide the cluster spec of the running replicas,
+	// thus they can not be added or removed.
+	fldPath := field.NewPath("spec", "tfReplicaSpecs")
+	for rType := range tfJob.Spec.TFReplicaSpecs {
+		if !hasReplicaType(oldTFJob.Spec.TFReplicaSpecs, rType) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Key(string(rType)), "replica types are immutable"))
+		}
+	}
+	for rType := range oldTFJob.Spec.TFReplicaSpecs {
+		if !hasReplicaType(tfJob.Spec.TFReplicaSpecs, rType) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Key(string(rType)), "replica types are immutable"))
+		}
+	}
+	return allErrs
+}
+
+// validateV1AdmissionRules checks the rules which are stricter than the ones
+// of the earlier versions of the operator for the fields the TFJobs could be
+// stored with before, e.g. the replica types or the success policy. Unlike
+// ValidateV1TFJobSpec, they are not checked for the TFJobs from the informer,
+// which would stop the TFJobs stored before from running after an upgrade.
+func validateV1AdmissionRules(tfJob *tfv1.TFJob) field.ErrorList {
+	allErrs := validateV1TFJobName(tfJob)
+	fldPath := field.NewPath("spec")
+	specsPath := fldPath.Child("tfReplicaSpecs")
+	for _, rType := range sortedReplicaTypes(tfJob.Spec.TFReplicaSpecs) {
+		value := tfJob.Spec.TFReplicaSpecs[rType]
+		rPath := specsPath.Key(string(rType))
+		if !isSupportedReplicaType(rType) {
+			allErrs = append(allErrs, field.NotSupported(rPath, rType, replicaTypeNames()))
+		}
+		if value == nil {
+			continue
+		}
+		if value.Replicas != nil && *value.Replicas < 0 {
+			allErrs = append(allErrs, field.Invalid(rPath.Child("replicas"), *value.Replicas, "must be greater than or equal to 0"))
+		}
+		if value.RestartPolicy != "" && !isSupported(string(value.RestartPolicy), validRestartPolicies) {
+			allErrs = append(allErrs, field.NotSupported(rPath.Child("restartPolicy"), value.RestartPolicy, validRestartPolicies))
+		}
+	}
+	if policy := tfJob.Spec.SuccessPolicy; policy != nil && !isSupported(string(*policy), validSuccessPolicies) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("successPolicy"), *policy, validSuccessPolicies))
+	}
+	allErrs = append(allErrs, validateV1RunPolicy(&tfJob.Spec.RunPolicy, fldPath.Child("runPolicy"))...)
+	return allErrs
+}
+
+func validateV1TFJobName(tfJob *tfv1.TFJob) field.ErrorList {
+	var allErrs field.ErrorList
+	if tfJob.Name == "" {
+		return allErrs
+	}
+	// The name of the TFJob is the prefix of the pods and services of its replicas,
+	// and the service names need to be valid DNS-1035 labels.
+	for _, msg := range apimachineryvalidation.NameIsDNS1035Label(tfJob.Name, true) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), tfJob.Name, msg))
+	}
+	return allErrs
+}
+
+func validateV1ClusterDomain(tfJob *tfv1.TFJob) field.ErrorList {
+	var allErrs field.ErrorList
+	clusterDomain, ok := tfJob.Annotations[tfv1.ClusterDomainAnnotation]
+	if !ok {
+		return allErrs
+	}
+	// A wrong cluster domain makes the replicas wait for addresses which never resolve.
+	fldPath := field.NewPath("metadata", "annotations").Key(tfv1.ClusterDomainAnnotation)
+	for _, msg := range utilvalidation.IsDNS1123Subdomain(clusterDomain) {
+		allErrs = append(allErrs, field.Invalid(fldPath, clusterDomain, msg))
+	}
+	return allErrs
+}
+
+func validateV1Addresses(tfJob *tfv1.TFJob) field.ErrorList {
+	allErrs := validateV1ClusterDomain(tfJob)
+	return append(allErrs, validateV1Hostnames(tfJob)...)
+}
+
+func validateV1Hostnames(tfJob *tfv1.TFJob) field.ErrorList {
+	var allErrs field.ErrorList
+	mode := tfJob.Spec.ClusterSpecMode
+	if tfJob.Name == "" || mode == nil || *mode != tfv1.ClusterSpecModeHostname {
+		return allErrs
+	}
+	// The names of the pods are their hostnames, which need to be valid DNS-1123 labels.
+	fldPath := field.NewPath("spec", "tfReplicaSpecs")
+	for _, rType := range sortedReplicaTypes(tfJob.Spec.TFReplicaSpecs) {
+		value := tfJob.Spec.TFReplicaSpecs[rType]
+		if value == nil || value.Replicas == nil || *value.Replicas <= 0 {
+			continue
+		}
+		replicas := *value.Replicas
+		if tfv1.IsWorker(rType) && tfJob.Spec.ElasticPolicy != nil && tfJob.Spec.ElasticPolicy.MaxReplicas != nil &&
+			*tfJob.Spec.ElasticPolicy.MaxReplicas > replicas {
+			replicas = *tfJob.Spec.ElasticPolicy.MaxReplicas
+		}
+		hostname := fmt.Sprintf("%s-%s-%d", tfJob.Name, strings.ToLower(string(rType)), replicas-1)
+		for _, msg := range utilvalidation.IsDNS1123Label(hostname) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(rType)), hostname,
+				fmt.Sprintf("hostname of the replica is invalid for cluster spec mode %s: %s", *mode, msg)))
+		}
+	}
+	return allErrs
+}
+
+func validateV1TFJobSpec(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateV1ReplicaSpecs(c, fldPath.Child("tfReplicaSpecs"))
+	allErrs = append(allErrs, validateV1ContainerNames(c, fldPath)...)
+	allErrs = append(allErrs, validateV1SuccessPolicy(c, fldPath)...)
+	allErrs = append(allErrs, validateV1FailurePolicies(c, fldPath.Child("failurePolicies"))...)
+	allErrs = append(allErrs, validateV1ExitCodePolicies(c, fldPath.Child("exitCodePolicies"))...)
+	allErrs = append(allErrs, validateV1ClusterSpecMembership(c, fldPath.Child("clusterSpecMembership"))...)
+	if c.ClusterSpecMode != nil && !isSupported(string(*c.ClusterSpecMode), validClusterSpecModes) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("clusterSpecMode"), *c.ClusterSpecMode, validClusterSpecModes))
+	}
+	if c.TFConfigDelivery != nil && !isSupported(string(*c.TFConfigDelivery), validTFConfigDeliveries) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("tfConfigDelivery"), *c.TFConfigDelivery, validTFConfigDeliveries))
+	}
+	allErrs = append(allErrs, validateV1ElasticPolicy(c, fldPath.Child("elasticPolicy"))...)
+	allErrs = append(allErrs, validateV1PendingTimeoutPolicy(c.PendingTimeoutPolicy, fldPath.Child("pendingTimeoutPolicy"))...)
+	if c.RestartScope != nil && !isSupported(string(*c.RestartScope), validRestartScopes) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("restartScope"), *c.RestartScope, validRestartScopes))
+	}
+	allErrs = append(allErrs, validateV1MemoryEscalationPolicy(c.MemoryEscalationPolicy, fldPath.Child("memoryEscalationPolicy"))...)
+	allErrs = append(allErrs, validateV1MinMembers(c, fldPath.Child("minMembers"))...)
+	if c.StartupPolicy != nil && c.StartupPolicy.Type != "" && !isSupported(string(c.StartupPolicy.Type), validStartupPolicyTypes) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("startupPolicy", "type"), c.StartupPolicy.Type, validStartupPolicyTypes))
+	}
+	return allErrs
+}
+
+func validateV1ReplicaSpecs(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	specs := c.TFReplicaSpecs
 	if specs == nil {
-		return fmt.Errorf("TFJobSpec is not valid")
+		return append(allErrs, field.Required(fldPath, "at least one replica type is required"))
 	}
 	foundChief := 0
-	for rType, value := range specs {
+	for _, rType := range sortedReplicaTypes(specs) {
+		value := specs[rType]
+		rPath := fldPath.Key(string(rType))
+		containersPath := rPath.Child("template", "spec", "containers")
 		if value == nil || len(value.Template.Spec.Containers) == 0 {
-			return fmt.Errorf("TFJobSpec is not valid: containers definition expected in %v", rType)
+			allErrs = append(allErrs, field.Required(containersPath,
+				fmt.Sprintf("containers definition expected in %v", rType)))
+			continue
 		}
-		if tfv1.IsChieforMaster(rType) {
+		if typ := commonv1.ReplicaType(strings.Title(strings.ToLower(string(rType)))); tfv1.IsChieforMaster(typ) || tfv1.IsCoordinator(typ) {
 			foundChief++
+			if tfv1.IsCoordinator(typ) && value.Replicas != nil && *value.Replicas != 1 {
+				allErrs = append(allErrs, field.Invalid(rPath.Child("replicas"), *value.Replicas, "must be 1 for the coordinator"))
+			}
 		}
 		// Make sure the image is defined in the container.
+		containerName := tfv1.GetContainerName(c, value.Template.Annotations)
 		numNamedTensorflow := 0
-		for _, container := range value.Template.Spec.Containers {
+		for i, container := range value.Template.Spec.Containers {
 			if container.Image == "" {
-				msg := fmt.Sprintf("TFJobSpec is not valid: Image is undefined in the container of %v", rType)
-				log.Error(msg)
-				return fmt.Errorf(msg)
+				allErrs = append(allErrs, field.Required(containersPath.Index(i).Child("image"),
+					fmt.Sprintf("Image is undefined in the container of %v", rType)))
 			}
-			if container.Name == tfv1.DefaultContainerName {
+			if container.Name == containerName {
 				numNamedTensorflow++
 			}
 		}
-		// Make sure there has at least one container named "tensorflow".
+		// Make sure there has at least one container named "tensorflow", or the
+		// container name of the replica type.
 		if numNamedTensorflow == 0 {
-			msg := fmt.Sprintf("TFJobSpec is not valid: There is no container named %s in %v", tfv1.DefaultContainerName, rType)
-			log.Error(msg)
-			return fmt.Errorf(msg)
+			allErrs = append(allErrs, field.Required(containersPath,
+				fmt.Sprintf("There is no container named %s in %v", containerName, rType)))
 		}
 	}
 	if foundChief > 1 {
-		return fmt.Errorf("TFJobSpec is not valid: more than 1 chief/master found")
+		allErrs = append(allErrs, field.Forbidden(fldPath, "more than 1 chief/master/coordinator found"))
+	}
+	return allErrs
+}
+
+func validateV1ContainerNames(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.ContainerName != nil {
+		for _, msg := range utilvalidation.IsDNS1123Label(*c.ContainerName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("containerName"), *c.ContainerName, msg))
+		}
+	}
+	if c.PortName != nil {
+		for _, msg := range utilvalidation.IsValidPortName(*c.PortName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("portName"), *c.PortName, msg))
+		}
+	}
+	for _, rType := range sortedReplicaTypes(c.TFReplicaSpecs) {
+		value := c.TFReplicaSpecs[rType]
+		if value == nil {
+			continue
+		}
+		annotationsPath := fldPath.Child("tfReplicaSpecs").Key(string(rType)).Child("template", "metadata", "annotations")
+		if name, ok := value.Template.Annotations[tfv1.ContainerNameAnnotation]; ok {
+			for _, msg := range utilvalidation.IsDNS1123Label(name) {
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key(tfv1.ContainerNameAnnotation), name, msg))
+			}
+		}
+		if name, ok := value.Template.Annotations[tfv1.PortNameAnnotation]; ok {
+			for _, msg := range utilvalidation.IsValidPortName(name) {
+				allErrs = append(allErrs, field.Invalid(annotationsPath.Key(tfv1.PortNameAnnotation), name, msg))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateV1PendingTimeoutPolicy(policy *tfv1.PendingTimeoutPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if policy == nil {
+		return allErrs
+	}
+	if policy.TimeoutSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), policy.TimeoutSeconds, "must be greater than 0"))
+	}
+	if policy.Action != "" && !isSupported(string(policy.Action), validPendingTimeoutActions) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("action"), policy.Action, validPendingTimeoutActions))
+	}
+	return allErrs
+}
+
+func validateV1MemoryEscalationPolicy(policy *tfv1.MemoryEscalationPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if policy == nil {
+		return allErrs
+	}
+	if policy.FactorPercent <= 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("factorPercent"), policy.FactorPercent, "must be greater than 100"))
+	}
+	if policy.MaxMemory.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxMemory"), policy.MaxMemory.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateV1SuccessPolicy(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	policy := tfv1.SuccessPolicyDefault
+	if c.SuccessPolicy != nil {
+		policy = *c.SuccessPolicy
+	}
+	policyPath := fldPath.Child("successPolicy")
+	thresholdPath := fldPath.Child("successThreshold")
+	// The unsupported success policies are only rejected by the admission webhook.
+	if !isSupported(string(policy), validSuccessPolicies) {
+		return allErrs
+	}
+
+	if (policy == tfv1.SuccessPolicyAnyWorker || policy == tfv1.SuccessPolicyWorkerThreshold) &&
+		!hasReplicaType(c.TFReplicaSpecs, tfv1.TFReplicaTypeWorker) {
+		allErrs = append(allErrs, field.Forbidden(policyPath,
+			fmt.Sprintf("success policy %s requires %v replicas", policy, tfv1.TFReplicaTypeWorker)))
+	}
+	if (policy == tfv1.SuccessPolicyAnyWorker || policy == tfv1.SuccessPolicyWorkerThreshold) &&
+		hasReplicaType(c.TFReplicaSpecs, tfv1.TFReplicaTypeCoordinator) {
+		allErrs = append(allErrs, field.Forbidden(policyPath,
+			fmt.Sprintf("success policy %s is not allowed with %v replicas", policy, tfv1.TFReplicaTypeCoordinator)))
+	}
+	if policy != tfv1.SuccessPolicyWorkerThreshold {
+		if c.SuccessThreshold != nil {
+			allErrs = append(allErrs, field.Forbidden(thresholdPath,
+				fmt.Sprintf("only allowed with success policy %s", tfv1.SuccessPolicyWorkerThreshold)))
+		}
+		return allErrs
+	}
+
+	threshold := c.SuccessThreshold
+	if threshold == nil {
+		return append(allErrs, field.Required(thresholdPath,
+			fmt.Sprintf("required with success policy %s", tfv1.SuccessPolicyWorkerThreshold)))
+	}
+	if threshold.Type == intstr.String {
+		for _, msg := range utilvalidation.IsValidPercent(threshold.StrVal) {
+			allErrs = append(allErrs, field.Invalid(thresholdPath, threshold.StrVal, msg))
+		}
+		if len(allErrs) != 0 {
+			return allErrs
+		}
+		percent, _ := strconv.Atoi(strings.TrimSuffix(threshold.StrVal, "%"))
+		if percent < 1 || percent > 100 {
+			allErrs = append(allErrs, field.Invalid(thresholdPath, threshold.StrVal, "must be between 1% and 100%"))
+		}
+		return allErrs
+	}
+	if threshold.IntVal < 1 {
+		return append(allErrs, field.Invalid(thresholdPath, threshold.IntVal, "must be greater than or equal to 1"))
+	}
+	// The number of workers of an elastic tfjob changes, thus it is only
+	// checked at runtime.
+	if c.ElasticPolicy == nil {
+		for rType, spec := range c.TFReplicaSpecs {
+			if strings.EqualFold(string(rType), string(tfv1.TFReplicaTypeWorker)) &&
+				spec != nil && spec.Replicas != nil && threshold.IntVal > *spec.Replicas {
+				allErrs = append(allErrs, field.Invalid(thresholdPath, threshold.IntVal,
+					fmt.Sprintf("must be less than or equal to the number of %v replicas %d", rType, *spec.Replicas)))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateV1ClusterSpecMembership(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for rType := range c.ClusterSpecMembership {
+		if !hasReplicaType(c.TFReplicaSpecs, rType) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(rType)), rType, "replica type is not in tfReplicaSpecs"))
+		}
+	}
+	return allErrs
+}
+
+func validateV1FailurePolicies(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for rType, policy := range c.FailurePolicies {
+		pPath := fldPath.Key(string(rType))
+		if !hasReplicaType(c.TFReplicaSpecs, rType) {
+			allErrs = append(allErrs, field.Invalid(pPath, rType, "replica type is not in tfReplicaSpecs"))
+			continue
+		}
+		if policy == nil {
+			continue
+		}
+		policyType := policy.Type
+		if policyType == "" {
+			policyType = tfv1.FailurePolicyFailJob
+		}
+		if !isSupported(string(policyType), validFailurePolicyTypes) {
+			allErrs = append(allErrs, field.NotSupported(pPath.Child("type"), policy.Type, validFailurePolicyTypes))
+			continue
+		}
+		if policyType != tfv1.FailurePolicyTolerate {
+			if policy.MaxFailures != nil {
+				allErrs = append(allErrs, field.Forbidden(pPath.Child("maxFailures"),
+					fmt.Sprintf("only allowed with failure policy type %s", tfv1.FailurePolicyTolerate)))
+			}
+			continue
+		}
+		if policy.MaxFailures == nil {
+			allErrs = append(allErrs, field.Required(pPath.Child("maxFailures"),
+				fmt.Sprintf("required with failure policy type %s", tfv1.FailurePolicyTolerate)))
+		} else if *policy.MaxFailures < 0 {
+			allErrs = append(allErrs, field.Invalid(pPath.Child("maxFailures"), *policy.MaxFailures,
+				"must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
+
+func validateV1ExitCodePolicies(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for rType, policy := range c.ExitCodePolicies {
+		pPath := fldPath.Key(string(rType))
+		spec := replicaSpec(c.TFReplicaSpecs, rType)
+		if spec == nil {
+			allErrs = append(allErrs, field.Invalid(pPath, rType, "replica type is not in tfReplicaSpecs"))
+			continue
+		}
+		if spec.RestartPolicy != commonv1.RestartPolicyExitCode {
+			allErrs = append(allErrs, field.Forbidden(pPath,
+				fmt.Sprintf("only allowed with restart policy %s", commonv1.RestartPolicyExitCode)))
+			continue
+		}
+		if policy == nil {
+			continue
+		}
+		allErrs = append(allErrs, validateV1ExitCodeRanges(policy.RetryableExitCodes, pPath.Child("retryableExitCodes"))...)
+		allErrs = append(allErrs, validateV1ExitCodeRanges(policy.PermanentExitCodes, pPath.Child("permanentExitCodes"))...)
+	}
+	return allErrs
+}
+
+func validateV1ExitCodeRanges(ranges []tfv1.ExitCodeRange, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, r := range ranges {
+		if r.Min < 0 || r.Min > 255 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("min"), r.Min, "must be between 0 and 255"))
+		}
+		if r.Max != nil && (*r.Max < r.Min || *r.Max > 255) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("max"), *r.Max,
+				fmt.Sprintf("must be between min %d and 255", r.Min)))
+		}
+	}
+	return allErrs
+}
+
+func validateV1ElasticPolicy(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	policy := c.ElasticPolicy
+	if policy == nil {
+		return allErrs
+	}
+	if !hasReplicaType(c.TFReplicaSpecs, tfv1.TFReplicaTypeWorker) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("elasticPolicy requires %v replicas", tfv1.TFReplicaTypeWorker)))
+	}
+	if !c.EnableDynamicWorker {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "elasticPolicy requires enableDynamicWorker"))
+	}
+	minReplicas := int32(1)
+	if policy.MinReplicas != nil {
+		minReplicas = *policy.MinReplicas
+	}
+	if minReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), minReplicas, "must be greater than or equal to 1"))
+	}
+	if policy.MaxReplicas != nil && *policy.MaxReplicas < minReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), *policy.MaxReplicas,
+			fmt.Sprintf("must be greater than or equal to minReplicas %d", minReplicas)))
+	}
+	// The replicas are scaled within the range of the policy.
+	if spec := replicaSpec(c.TFReplicaSpecs, tfv1.TFReplicaTypeWorker); spec != nil && spec.Replicas != nil {
+		replicas := *spec.Replicas
+		if replicas < minReplicas {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), minReplicas,
+				fmt.Sprintf("must be less than or equal to the number of %v replicas %d", tfv1.TFReplicaTypeWorker, replicas)))
+		}
+		if policy.MaxReplicas != nil && replicas > *policy.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), *policy.MaxReplicas,
+				fmt.Sprintf("must be greater than or equal to the number of %v replicas %d", tfv1.TFReplicaTypeWorker, replicas)))
+		}
+	}
+	return allErrs
+}
+
+// validateV1MinMembers checks that the minimum members never split the PS, Chief,
+// Master and Coordinator replicas, and that they include the Worker replicas
+// deciding the success of the TFJob according to its success policy.
+func validateV1MinMembers(c *tfv1.TFJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(c.MinMembers) == 0 {
+		return allErrs
+	}
+	if sp := c.RunPolicy.SchedulingPolicy; sp != nil && sp.MinAvailable != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "not allowed with schedulingPolicy.minAvailable"))
+	}
+
+	minWorkers := int32(-1)
+	for rType, minMembers := range c.MinMembers {
+		mPath := fldPath.Key(string(rType))
+		spec := replicaSpec(c.TFReplicaSpecs, rType)
+		if spec == nil {
+			allErrs = append(allErrs, field.Invalid(mPath, rType, "replica type is not in tfReplicaSpecs"))
+			continue
+		}
+		if minMembers < 0 {
+			allErrs = append(allErrs, field.Invalid(mPath, minMembers, "must be greater than or equal to 0"))
+			continue
+		}
+		isWorker := strings.EqualFold(string(rType), string(tfv1.TFReplicaTypeWorker))
+		if isWorker {
+			minWorkers = minMembers
+		}
+		if spec.Replicas == nil || (isWorker && c.ElasticPolicy != nil) {
+			// The number of workers of an elastic tfjob changes, thus it is
+			// only bounded at runtime.
+			continue
+		}
+		if minMembers > *spec.Replicas {
+			allErrs = append(allErrs, field.Invalid(mPath, minMembers,
+				fmt.Sprintf("must be less than or equal to the number of %v replicas %d", rType, *spec.Replicas)))
+			continue
+		}
+		for _, t := range []commonv1.ReplicaType{tfv1.TFReplicaTypePS, tfv1.TFReplicaTypeChief,
+			tfv1.TFReplicaTypeMaster, tfv1.TFReplicaTypeCoordinator} {
+			if strings.EqualFold(string(rType), string(t)) && minMembers != *spec.Replicas {
+				allErrs = append(allErrs, field.Invalid(mPath, minMembers,
+					fmt.Sprintf("must be the number of %v replicas %d, since they are never split", rType, *spec.Replicas)))
+			}
+		}
+	}
+	if minWorkers < 0 {
+		return allErrs
+	}
+
+	policy := tfv1.SuccessPolicyDefault
+	if c.SuccessPolicy != nil {
+		policy = *c.SuccessPolicy
+	}
+	wPath := fldPath.Key(string(tfv1.TFReplicaTypeWorker))
+	hasChief := hasReplicaType(c.TFReplicaSpecs, tfv1.TFReplicaTypeChief) ||
+		hasReplicaType(c.TFReplicaSpecs, tfv1.TFReplicaTypeMaster)
+	switch policy {
+	case tfv1.SuccessPolicyDefault, tfv1.SuccessPolicyChiefOnly, tfv1.SuccessPolicyAnyWorker:
+		// Worker 0 is the chief if there is no Chief or Master replica.
+		if minWorkers < 1 && (policy == tfv1.SuccessPolicyAnyWorker || !hasChief) {
+			allErrs = append(allErrs, field.Invalid(wPath, minWorkers,
+				fmt.Sprintf("must be greater than or equal to 1 with success policy %q", policy)))
+		}
+	case tfv1.SuccessPolicyAllWorkers:
+		spec := replicaSpec(c.TFReplicaSpecs, tfv1.TFReplicaTypeWorker)
+		if !hasChief && c.ElasticPolicy == nil && spec != nil && spec.Replicas != nil && minWorkers != *spec.Replicas {
+			allErrs = append(allErrs, field.Invalid(wPath, minWorkers,
+				fmt.Sprintf("must be the number of %v replicas %d with success policy %q",
+					tfv1.TFReplicaTypeWorker, *spec.Replicas, policy)))
+		}
+	case tfv1.SuccessPolicyWorkerThreshold:
+		if t := c.SuccessThreshold; t != nil && t.Type == intstr.Int && minWorkers < t.IntVal {
+			allErrs = append(allErrs, field.Invalid(wPath, minWorkers,
+				fmt.Sprintf("must be greater than or equal to the success threshold %d", t.IntVal)))
+		}
+	}
+	return allErrs
+}
+
+func validateV1RunPolicy(runPolicy *commonv1.RunPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if runPolicy.CleanPodPolicy != nil && !isSupported(string(*runPolicy.CleanPodPolicy), validCleanPodPolicies) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cleanPodPolicy"), *runPolicy.CleanPodPolicy, validCleanPodPolicies))
+	}
+	if runPolicy.TTLSecondsAfterFinished != nil && *runPolicy.TTLSecondsAfterFinished < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttlSecondsAfterFinished"),
+			*runPolicy.TTLSecondsAfterFinished, "must be greater than or equal to 0"))
+	}
+	if runPolicy.ActiveDeadlineSeconds != nil && *runPolicy.ActiveDeadlineSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("activeDeadlineSeconds"),
+			*runPolicy.ActiveDeadlineSeconds, "must be greater than 0"))
+	}
+	if runPolicy.BackoffLimit != nil && *runPolicy.BackoffLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("backoffLimit"),
+			*runPolicy.BackoffLimit, "must be greater than or equal to 0"))
+	}
+	if sp := runPolicy.SchedulingPolicy; sp != nil && sp.MinAvailable != nil && *sp.MinAvailable < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedulingPolicy", "minAvailable"),
+			*sp.MinAvailable, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// hasReplicaType returns true if the specs contain the replica type. The replica
+// types are compared case-insensitively since they are camel-cased by defaulting.
+func hasReplicaType(specs map[commonv1.ReplicaType]*commonv1.ReplicaSpec, rType commonv1.ReplicaType) bool {
+	for t := range specs {
+		if strings.EqualFold(string(t), string(rType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// replicaSpec returns the spec of the replica type, which is compared case-insensitively.
+func replicaSpec(specs map[commonv1.ReplicaType]*commonv1.ReplicaSpec, rType commonv1.ReplicaType) *commonv1.ReplicaSpec {
+	for t, spec := range specs {
+		if strings.EqualFold(string(t), string(rType)) {
+			return spec
+		}
 	}
 	return nil
 }
+
+func isSupportedReplicaType(rType commonv1.ReplicaType) bool {
+	for _, t := range validReplicaTypes {
+		if strings.EqualFold(string(t), string(rType)) {
+			return true
+		}
+	}
+	return false
+}
+
+func replicaTypeNames() []string {
+	names := make([]string, 0, len(validReplicaTypes))
+	for _, t := range validReplicaTypes {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+func sortedReplicaTypes(specs map[commonv1.ReplicaType]*commonv1.ReplicaSpec) []commonv1.ReplicaType {
+	types := make([]commonv1.ReplicaType, 0, len(specs))
+	for t := range specs {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+func isSupported(value string, supported []string) bool {
+	for _, s := range supported {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
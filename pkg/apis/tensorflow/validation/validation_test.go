@@ -15,12 +15,16 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestValidateV1TFJobSpec(t *testing.T) {
@@ -96,3 +100,672 @@ func TestValidateV1TFJobSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateV1ElasticPolicy(t *testing.T) {
+	newSpec := func(enableDynamicWorker bool, policy *tfv1.ElasticPolicy) tfv1.TFJobSpec {
+		return tfv1.TFJobSpec{
+			EnableDynamicWorker: enableDynamicWorker,
+			ElasticPolicy:       policy,
+			TFReplicaSpecs: map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+				tfv1.TFReplicaTypeWorker: &commonv1.ReplicaSpec{
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								v1.Container{
+									Name:  tfv1.DefaultContainerName,
+									Image: "kubeflow/tf-dist-mnist-test:1.0",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	withWorkers := func(spec tfv1.TFJobSpec, replicas int32) tfv1.TFJobSpec {
+		spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Replicas = tfv1.Int32(replicas)
+		return spec
+	}
+
+	testCases := map[string]struct {
+		spec        tfv1.TFJobSpec
+		expectedErr bool
+	}{
+		"no elastic policy": {
+			spec:        newSpec(false, nil),
+			expectedErr: false,
+		},
+		"valid elastic policy": {
+			spec:        newSpec(true, &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(1), MaxReplicas: tfv1.Int32(4)}),
+			expectedErr: false,
+		},
+		"dynamic worker is disabled": {
+			spec:        newSpec(false, &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(1), MaxReplicas: tfv1.Int32(4)}),
+			expectedErr: true,
+		},
+		"min replicas is zero": {
+			spec:        newSpec(true, &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(0)}),
+			expectedErr: true,
+		},
+		"max replicas is less than min replicas": {
+			spec:        newSpec(true, &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(3), MaxReplicas: tfv1.Int32(2)}),
+			expectedErr: true,
+		},
+		"replicas within the range": {
+			spec:        withWorkers(newSpec(true, &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(1), MaxReplicas: tfv1.Int32(4)}), 4),
+			expectedErr: false,
+		},
+		"replicas below min replicas": {
+			spec:        withWorkers(newSpec(true, &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(2), MaxReplicas: tfv1.Int32(4)}), 1),
+			expectedErr: true,
+		},
+		"replicas above max replicas": {
+			spec:        withWorkers(newSpec(true, &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(1), MaxReplicas: tfv1.Int32(4)}), 5),
+			expectedErr: true,
+		},
+	}
+	for name, c := range testCases {
+		err := ValidateV1TFJobSpec(&c.spec)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("%s: Expected error %v, got %v", name, c.expectedErr, err)
+		}
+	}
+}
+
+func newValidTFJob() *tfv1.TFJob {
+	return &tfv1.TFJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-tfjob",
+		},
+		Spec: tfv1.TFJobSpec{
+			TFReplicaSpecs: map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+				tfv1.TFReplicaTypeWorker: &commonv1.ReplicaSpec{
+					Replicas: tfv1.Int32(2),
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{
+								v1.Container{
+									Name:  tfv1.DefaultContainerName,
+									Image: "kubeflow/tf-dist-mnist-test:1.0",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateV1TFJob(t *testing.T) {
+	testCases := map[string]struct {
+		mutate        func(*tfv1.TFJob)
+		expectedField string
+	}{
+		"valid tfjob": {
+			mutate:        func(*tfv1.TFJob) {},
+			expectedField: "",
+		},
+		"invalid name": {
+			mutate:        func(j *tfv1.TFJob) { j.Name = "Test_TFJob" },
+			expectedField: "metadata.name",
+		},
+		"invalid cluster domain": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Annotations = map[string]string{tfv1.ClusterDomainAnnotation: "Cluster_Local"}
+			},
+			expectedField: "metadata.annotations[kubeflow.org/cluster-domain]",
+		},
+		"missing image": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.Spec.Containers[0].Image = ""
+			},
+			expectedField: "spec.tfReplicaSpecs[Worker].template.spec.containers[0].image",
+		},
+		"negative replicas": {
+			mutate:        func(j *tfv1.TFJob) { j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Replicas = tfv1.Int32(-1) },
+			expectedField: "spec.tfReplicaSpecs[Worker].replicas",
+		},
+		"unknown replica type": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.TFReplicaSpecs["Trainer"] = j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+				delete(j.Spec.TFReplicaSpecs, tfv1.TFReplicaTypeWorker)
+			},
+			expectedField: "spec.tfReplicaSpecs[Trainer]",
+		},
+		"unknown restart policy": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].RestartPolicy = "Sometimes"
+			},
+			expectedField: "spec.tfReplicaSpecs[Worker].restartPolicy",
+		},
+		"negative backoff limit": {
+			mutate:        func(j *tfv1.TFJob) { j.Spec.RunPolicy.BackoffLimit = tfv1.Int32(-1) },
+			expectedField: "spec.runPolicy.backoffLimit",
+		},
+		"unknown cluster spec mode": {
+			mutate: func(j *tfv1.TFJob) {
+				mode := tfv1.ClusterSpecMode("NodeName")
+				j.Spec.ClusterSpecMode = &mode
+			},
+			expectedField: "spec.clusterSpecMode",
+		},
+		"unknown tf config delivery": {
+			mutate: func(j *tfv1.TFJob) {
+				delivery := tfv1.TFConfigDelivery("Secret")
+				j.Spec.TFConfigDelivery = &delivery
+			},
+			expectedField: "spec.tfConfigDelivery",
+		},
+		"custom container name of the tfjob": {
+			mutate: func(j *tfv1.TFJob) {
+				name := "trainer"
+				j.Spec.ContainerName = &name
+				j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.Spec.Containers[0].Name = name
+			},
+			expectedField: "",
+		},
+		"custom container name of the replica type": {
+			mutate: func(j *tfv1.TFJob) {
+				template := &j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template
+				template.Annotations = map[string]string{tfv1.ContainerNameAnnotation: "trainer"}
+				template.Spec.Containers[0].Name = "trainer"
+			},
+			expectedField: "",
+		},
+		"missing custom container": {
+			mutate: func(j *tfv1.TFJob) {
+				name := "trainer"
+				j.Spec.ContainerName = &name
+			},
+			expectedField: "spec.tfReplicaSpecs[Worker].template.spec.containers",
+		},
+		"invalid port name": {
+			mutate: func(j *tfv1.TFJob) {
+				name := "trainer_port"
+				j.Spec.PortName = &name
+			},
+			expectedField: "spec.portName",
+		},
+		"invalid container name annotation": {
+			mutate: func(j *tfv1.TFJob) {
+				template := &j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template
+				template.Annotations = map[string]string{tfv1.ContainerNameAnnotation: "Trainer"}
+				template.Spec.Containers[0].Name = "Trainer"
+			},
+			expectedField: "spec.tfReplicaSpecs[Worker].template.metadata.annotations[kubeflow.org/container-name]",
+		},
+		"valid hostname cluster spec mode": {
+			mutate: func(j *tfv1.TFJob) {
+				mode := tfv1.ClusterSpecModeHostname
+				j.Spec.ClusterSpecMode = &mode
+			},
+			expectedField: "",
+		},
+		"too long hostname": {
+			mutate: func(j *tfv1.TFJob) {
+				mode := tfv1.ClusterSpecModeHostname
+				j.Spec.ClusterSpecMode = &mode
+				j.Name = strings.Repeat("a", 55)
+			},
+			expectedField: "spec.tfReplicaSpecs[Worker]",
+		},
+		"unknown startup policy": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.StartupPolicy = &tfv1.StartupPolicy{Type: "Sequential"}
+			},
+			expectedField: "spec.startupPolicy.type",
+		},
+		"valid pending timeout policy": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.PendingTimeoutPolicy = &tfv1.PendingTimeoutPolicy{TimeoutSeconds: 600, Action: tfv1.PendingTimeoutActionSuspend}
+			},
+			expectedField: "",
+		},
+		"zero pending timeout": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.PendingTimeoutPolicy = &tfv1.PendingTimeoutPolicy{}
+			},
+			expectedField: "spec.pendingTimeoutPolicy.timeoutSeconds",
+		},
+		"unknown pending timeout action": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.PendingTimeoutPolicy = &tfv1.PendingTimeoutPolicy{TimeoutSeconds: 600, Action: "Delete"}
+			},
+			expectedField: "spec.pendingTimeoutPolicy.action",
+		},
+		"valid memory escalation policy": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.MemoryEscalationPolicy = &tfv1.MemoryEscalationPolicy{FactorPercent: 150, MaxMemory: resource.MustParse("64Gi")}
+			},
+			expectedField: "",
+		},
+		"memory escalation factor not raising memory": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.MemoryEscalationPolicy = &tfv1.MemoryEscalationPolicy{FactorPercent: 100, MaxMemory: resource.MustParse("64Gi")}
+			},
+			expectedField: "spec.memoryEscalationPolicy.factorPercent",
+		},
+		"memory escalation without ceiling": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.MemoryEscalationPolicy = &tfv1.MemoryEscalationPolicy{FactorPercent: 150}
+			},
+			expectedField: "spec.memoryEscalationPolicy.maxMemory",
+		},
+		"job restart scope": {
+			mutate: func(j *tfv1.TFJob) {
+				scope := tfv1.RestartScopeJob
+				j.Spec.RestartScope = &scope
+			},
+			expectedField: "",
+		},
+		"unknown restart scope": {
+			mutate: func(j *tfv1.TFJob) {
+				scope := tfv1.RestartScope("Node")
+				j.Spec.RestartScope = &scope
+			},
+			expectedField: "spec.restartScope",
+		},
+		"valid coordinator": {
+			mutate: func(j *tfv1.TFJob) {
+				coordinator := j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].DeepCopy()
+				coordinator.Replicas = tfv1.Int32(1)
+				j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeCoordinator] = coordinator
+			},
+			expectedField: "",
+		},
+		"multiple coordinator replicas": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeCoordinator] = j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].DeepCopy()
+			},
+			expectedField: "spec.tfReplicaSpecs[Coordinator].replicas",
+		},
+		"coordinator with chief": {
+			mutate: func(j *tfv1.TFJob) {
+				coordinator := j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].DeepCopy()
+				coordinator.Replicas = tfv1.Int32(1)
+				j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeCoordinator] = coordinator
+				j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeChief] = coordinator.DeepCopy()
+			},
+			expectedField: "spec.tfReplicaSpecs",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := newValidTFJob()
+		c.mutate(tfJob)
+		errs := ValidateV1TFJob(tfJob)
+		if c.expectedField == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: Expected no error, got %v", name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Field != c.expectedField {
+			t.Errorf("%s: Expected an error on %s, got %v", name, c.expectedField, errs)
+		}
+	}
+}
+
+func TestValidateV1TFJobUpdate(t *testing.T) {
+	oldTFJob := newValidTFJob()
+
+	tfJob := newValidTFJob()
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Replicas = tfv1.Int32(4)
+	if errs := ValidateV1TFJobUpdate(tfJob, oldTFJob); len(errs) != 0 {
+		t.Errorf("Expected scaling the workers to be allowed, got %v", errs)
+	}
+
+	tfJob = newValidTFJob()
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypePS] = tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].DeepCopy()
+	errs := ValidateV1TFJobUpdate(tfJob, oldTFJob)
+	if len(errs) != 1 || errs[0].Field != "spec.tfReplicaSpecs[PS]" {
+		t.Errorf("Expected adding a replica type to be forbidden, got %v", errs)
+	}
+
+	tfJob = newValidTFJob()
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Replicas = tfv1.Int32(-1)
+	errs = ValidateV1TFJobUpdate(tfJob, oldTFJob)
+	if len(errs) != 1 || errs[0].Field != "spec.tfReplicaSpecs[Worker].replicas" {
+		t.Errorf("Expected negative replicas to be invalid, got %v", errs)
+	}
+
+	// The TFJob stored before the admission webhook breaks its rules, which
+	// are not checked on update then.
+	successPolicy := tfv1.SuccessPolicy("SomeWorkers")
+	oldTFJob.Spec.SuccessPolicy = &successPolicy
+	tfJob = oldTFJob.DeepCopy()
+	suspend := true
+	tfJob.Spec.Suspend = &suspend
+	if errs := ValidateV1TFJobUpdate(tfJob, oldTFJob); len(errs) != 0 {
+		t.Errorf("Expected updating the stored tfjob to be allowed, got %v", errs)
+	}
+}
+
+func TestValidateV1StoredTFJob(t *testing.T) {
+	// The TFJobs from the informer are not checked against the rules of the
+	// admission webhook, which the TFJobs stored before may break.
+	tfJob := newValidTFJob()
+	tfJob.Spec.TFReplicaSpecs["Trainer"] = tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+	delete(tfJob.Spec.TFReplicaSpecs, tfv1.TFReplicaTypeWorker)
+	successPolicy := tfv1.SuccessPolicy("SomeWorkers")
+	tfJob.Spec.SuccessPolicy = &successPolicy
+	if err := ValidateV1TFJobSpec(&tfJob.Spec); err != nil {
+		t.Errorf("Expected the stored tfjob to be valid, got %v", err)
+	}
+	if errs := ValidateV1TFJob(tfJob); len(errs) != 2 {
+		t.Errorf("Expected the replica type and the success policy to be rejected on creation, got %v", errs)
+	}
+}
+
+func TestValidateV1SuccessPolicy(t *testing.T) {
+	policy := func(p tfv1.SuccessPolicy) *tfv1.SuccessPolicy { return &p }
+	threshold := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+
+	testCases := map[string]struct {
+		policy        *tfv1.SuccessPolicy
+		threshold     *intstr.IntOrString
+		expectedField string
+	}{
+		"default policy": {
+			policy: nil,
+		},
+		"any worker": {
+			policy: policy(tfv1.SuccessPolicyAnyWorker),
+		},
+		"worker threshold": {
+			policy:    policy(tfv1.SuccessPolicyWorkerThreshold),
+			threshold: threshold(intstr.FromInt(2)),
+		},
+		"worker threshold percentage": {
+			policy:    policy(tfv1.SuccessPolicyWorkerThreshold),
+			threshold: threshold(intstr.FromString("50%")),
+		},
+		"unknown policy": {
+			policy:        policy("SomeWorkers"),
+			expectedField: "spec.successPolicy",
+		},
+		"missing threshold": {
+			policy:        policy(tfv1.SuccessPolicyWorkerThreshold),
+			expectedField: "spec.successThreshold",
+		},
+		"threshold above worker replicas": {
+			policy:        policy(tfv1.SuccessPolicyWorkerThreshold),
+			threshold:     threshold(intstr.FromInt(3)),
+			expectedField: "spec.successThreshold",
+		},
+		"invalid percentage": {
+			policy:        policy(tfv1.SuccessPolicyWorkerThreshold),
+			threshold:     threshold(intstr.FromString("150%")),
+			expectedField: "spec.successThreshold",
+		},
+		"threshold without worker threshold policy": {
+			policy:        policy(tfv1.SuccessPolicyAllWorkers),
+			threshold:     threshold(intstr.FromInt(1)),
+			expectedField: "spec.successThreshold",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := newValidTFJob()
+		tfJob.Spec.SuccessPolicy = c.policy
+		tfJob.Spec.SuccessThreshold = c.threshold
+		errs := ValidateV1TFJob(tfJob)
+		if c.expectedField == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: Expected no error, got %v", name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Field != c.expectedField {
+			t.Errorf("%s: Expected an error on %s, got %v", name, c.expectedField, errs)
+		}
+	}
+}
+
+func TestValidateV1FailurePolicies(t *testing.T) {
+	testCases := map[string]struct {
+		policies      map[commonv1.ReplicaType]*tfv1.FailurePolicy
+		expectedField string
+	}{
+		"no failure policies": {
+			policies: nil,
+		},
+		"tolerate worker failures": {
+			policies: map[commonv1.ReplicaType]*tfv1.FailurePolicy{
+				tfv1.TFReplicaTypeWorker: {Type: tfv1.FailurePolicyTolerate, MaxFailures: tfv1.Int32(1)},
+			},
+		},
+		"unknown replica type": {
+			policies: map[commonv1.ReplicaType]*tfv1.FailurePolicy{
+				tfv1.TFReplicaTypeEval: {Type: tfv1.FailurePolicyIgnore},
+			},
+			expectedField: "spec.failurePolicies[Evaluator]",
+		},
+		"unknown policy type": {
+			policies: map[commonv1.ReplicaType]*tfv1.FailurePolicy{
+				tfv1.TFReplicaTypeWorker: {Type: "Retry"},
+			},
+			expectedField: "spec.failurePolicies[Worker].type",
+		},
+		"missing max failures": {
+			policies: map[commonv1.ReplicaType]*tfv1.FailurePolicy{
+				tfv1.TFReplicaTypeWorker: {Type: tfv1.FailurePolicyTolerate},
+			},
+			expectedField: "spec.failurePolicies[Worker].maxFailures",
+		},
+		"max failures without tolerate": {
+			policies: map[commonv1.ReplicaType]*tfv1.FailurePolicy{
+				tfv1.TFReplicaTypeWorker: {Type: tfv1.FailurePolicyIgnore, MaxFailures: tfv1.Int32(1)},
+			},
+			expectedField: "spec.failurePolicies[Worker].maxFailures",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := newValidTFJob()
+		tfJob.Spec.FailurePolicies = c.policies
+		errs := ValidateV1TFJob(tfJob)
+		if c.expectedField == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: Expected no error, got %v", name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Field != c.expectedField {
+			t.Errorf("%s: Expected an error on %s, got %v", name, c.expectedField, errs)
+		}
+	}
+}
+
+func TestValidateV1ClusterSpecMembership(t *testing.T) {
+	testCases := map[string]struct {
+		membership    map[commonv1.ReplicaType]bool
+		expectedField string
+	}{
+		"no cluster spec membership": {
+			membership: nil,
+		},
+		"exclude workers": {
+			membership: map[commonv1.ReplicaType]bool{
+				tfv1.TFReplicaTypeWorker: false,
+			},
+		},
+		"unknown replica type": {
+			membership: map[commonv1.ReplicaType]bool{
+				tfv1.TFReplicaTypeEval: true,
+			},
+			expectedField: "spec.clusterSpecMembership[Evaluator]",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := newValidTFJob()
+		tfJob.Spec.ClusterSpecMembership = c.membership
+		errs := ValidateV1TFJob(tfJob)
+		if c.expectedField == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: Expected no error, got %v", name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Field != c.expectedField {
+			t.Errorf("%s: Expected an error on %s, got %v", name, c.expectedField, errs)
+		}
+	}
+}
+
+func TestValidateV1ExitCodePolicies(t *testing.T) {
+	testCases := map[string]struct {
+		restartPolicy commonv1.RestartPolicy
+		policy        *tfv1.ExitCodePolicy
+		expectedField string
+	}{
+		"valid exit code policy": {
+			restartPolicy: commonv1.RestartPolicyExitCode,
+			policy: &tfv1.ExitCodePolicy{
+				RetryableExitCodes: []tfv1.ExitCodeRange{{Min: 130, Max: tfv1.Int32(143)}},
+				PermanentExitCodes: []tfv1.ExitCodeRange{{Min: 3}},
+			},
+		},
+		"restart policy is not ExitCode": {
+			restartPolicy: commonv1.RestartPolicyNever,
+			policy:        &tfv1.ExitCodePolicy{PermanentExitCodes: []tfv1.ExitCodeRange{{Min: 3}}},
+			expectedField: "spec.exitCodePolicies[Worker]",
+		},
+		"exit code out of range": {
+			restartPolicy: commonv1.RestartPolicyExitCode,
+			policy:        &tfv1.ExitCodePolicy{PermanentExitCodes: []tfv1.ExitCodeRange{{Min: 256}}},
+			expectedField: "spec.exitCodePolicies[Worker].permanentExitCodes[0].min",
+		},
+		"max is less than min": {
+			restartPolicy: commonv1.RestartPolicyExitCode,
+			policy:        &tfv1.ExitCodePolicy{RetryableExitCodes: []tfv1.ExitCodeRange{{Min: 143, Max: tfv1.Int32(130)}}},
+			expectedField: "spec.exitCodePolicies[Worker].retryableExitCodes[0].max",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := newValidTFJob()
+		tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].RestartPolicy = c.restartPolicy
+		tfJob.Spec.ExitCodePolicies = map[commonv1.ReplicaType]*tfv1.ExitCodePolicy{
+			tfv1.TFReplicaTypeWorker: c.policy,
+		}
+		errs := ValidateV1TFJob(tfJob)
+		if c.expectedField == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: Expected no error, got %v", name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Field != c.expectedField {
+			t.Errorf("%s: Expected an error on %s, got %v", name, c.expectedField, errs)
+		}
+	}
+}
+
+func TestValidateV1MinMembers(t *testing.T) {
+	policy := func(p tfv1.SuccessPolicy) *tfv1.SuccessPolicy { return &p }
+	withPS := func(j *tfv1.TFJob) {
+		j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypePS] = j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].DeepCopy()
+	}
+	withChief := func(j *tfv1.TFJob) {
+		chief := j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].DeepCopy()
+		chief.Replicas = tfv1.Int32(1)
+		j.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeChief] = chief
+	}
+
+	testCases := map[string]struct {
+		mutate        func(*tfv1.TFJob)
+		minMembers    map[commonv1.ReplicaType]int32
+		expectedField string
+	}{
+		"partial workers": {
+			minMembers: map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 1},
+		},
+		"all ps and partial workers": {
+			mutate:     withPS,
+			minMembers: map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypePS: 2, tfv1.TFReplicaTypeWorker: 1},
+		},
+		"no workers with chief": {
+			mutate:     withChief,
+			minMembers: map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 0},
+		},
+		"split ps": {
+			mutate:        withPS,
+			minMembers:    map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypePS: 1},
+			expectedField: "spec.minMembers[PS]",
+		},
+		"unknown replica type": {
+			minMembers:    map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeEval: 1},
+			expectedField: "spec.minMembers[Evaluator]",
+		},
+		"more than worker replicas": {
+			minMembers:    map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 3},
+			expectedField: "spec.minMembers[Worker]",
+		},
+		"more than elastic worker replicas": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.EnableDynamicWorker = true
+				j.Spec.ElasticPolicy = &tfv1.ElasticPolicy{MaxReplicas: tfv1.Int32(4)}
+			},
+			minMembers: map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 3},
+		},
+		"no workers without chief": {
+			minMembers:    map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 0},
+			expectedField: "spec.minMembers[Worker]",
+		},
+		"partial workers with all workers policy": {
+			mutate:        func(j *tfv1.TFJob) { j.Spec.SuccessPolicy = policy(tfv1.SuccessPolicyAllWorkers) },
+			minMembers:    map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 1},
+			expectedField: "spec.minMembers[Worker]",
+		},
+		"fewer workers than success threshold": {
+			mutate: func(j *tfv1.TFJob) {
+				threshold := intstr.FromInt(2)
+				j.Spec.SuccessPolicy = policy(tfv1.SuccessPolicyWorkerThreshold)
+				j.Spec.SuccessThreshold = &threshold
+			},
+			minMembers:    map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 1},
+			expectedField: "spec.minMembers[Worker]",
+		},
+		"with min available": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.RunPolicy.SchedulingPolicy = &commonv1.SchedulingPolicy{MinAvailable: tfv1.Int32(1)}
+			},
+			minMembers:    map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 1},
+			expectedField: "spec.minMembers",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := newValidTFJob()
+		if c.mutate != nil {
+			c.mutate(tfJob)
+		}
+		tfJob.Spec.MinMembers = c.minMembers
+		errs := ValidateV1TFJob(tfJob)
+		if c.expectedField == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: Expected no error, got %v", name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Field != c.expectedField {
+			t.Errorf("%s: Expected an error on %s, got %v", name, c.expectedField, errs)
+		}
+	}
+}
+
+func TestValidateV1TFJobAddresses(t *testing.T) {
+	mode := tfv1.ClusterSpecModeHostname
+	tfJob := newValidTFJob()
+	tfJob.Spec.ClusterSpecMode = &mode
+	if err := ValidateV1TFJobAddresses(tfJob); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	tfJob.Name = strings.Repeat("a", 55)
+	if err := ValidateV1TFJobAddresses(tfJob); err == nil {
+		t.Errorf("Expected an error for the too long hostnames")
+	}
+	tfJob = newValidTFJob()
+	tfJob.Annotations = map[string]string{tfv1.ClusterDomainAnnotation: "Cluster_Local"}
+	if err := ValidateV1TFJobAddresses(tfJob); err == nil {
+		t.Errorf("Expected an error for the invalid cluster domain annotation")
+	}
+}
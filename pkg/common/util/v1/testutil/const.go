@@ -19,11 +19,12 @@ import (
 )
 
 const (
-	TestImageName = "test-image-for-kubeflow-tf-operator:latest"
-	TestTFJobName = "test-tfjob"
-	LabelWorker   = "worker"
-	LabelPS       = "ps"
-	LabelChief    = "chief"
+	TestImageName    = "test-image-for-kubeflow-tf-operator:latest"
+	TestTFJobName    = "test-tfjob"
+	LabelWorker      = "worker"
+	LabelPS          = "ps"
+	LabelChief       = "chief"
+	LabelCoordinator = "coordinator"
 
 	SleepInterval = 500 * time.Millisecond
 	ThreadCount   = 1
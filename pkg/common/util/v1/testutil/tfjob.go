@@ -19,6 +19,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
@@ -92,6 +93,16 @@ func NewTFJobWithChief(worker, ps int) *tfv1.TFJob {
 	return tfJob
 }
 
+func NewTFJobWithCoordinator(worker, ps int) *tfv1.TFJob {
+	tfJob := NewTFJob(worker, ps)
+	coordinator := int32(1)
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeCoordinator] = &commonv1.ReplicaSpec{
+		Replicas: &coordinator,
+		Template: NewTFReplicaSpecTemplate(),
+	}
+	return tfJob
+}
+
 func NewTFJobWithEvaluator(worker, ps, evaluator int) *tfv1.TFJob {
 	tfJob := NewTFJob(worker, ps)
 	if evaluator > 0 {
@@ -110,6 +121,20 @@ func NewTFJobWithSuccessPolicy(worker, ps int, successPolicy tfv1.SuccessPolicy)
 	return tfJob
 }
 
+func NewTFJobWithSuccessThreshold(worker, ps int, threshold intstr.IntOrString) *tfv1.TFJob {
+	tfJob := NewTFJobWithSuccessPolicy(worker, ps, tfv1.SuccessPolicyWorkerThreshold)
+	tfJob.Spec.SuccessThreshold = &threshold
+	return tfJob
+}
+
+func NewTFJobWithFailurePolicy(worker, ps int, rtype commonv1.ReplicaType, policy tfv1.FailurePolicy) *tfv1.TFJob {
+	tfJob := NewTFJob(worker, ps)
+	tfJob.Spec.FailurePolicies = map[commonv1.ReplicaType]*tfv1.FailurePolicy{
+		rtype: &policy,
+	}
+	return tfJob
+}
+
 func NewTFJob(worker, ps int) *tfv1.TFJob {
 	tfJob := &tfv1.TFJob{
 		TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,173 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+const (
+	// tfClusterSpecFile is the environment variable name of the file containing
+	// TensorFlow cluster spec for TFConfigDeliveryConfigMap.
+	tfClusterSpecFile = "TF_CLUSTER_SPEC_FILE"
+	// tfClusterSpecKey is the key of the cluster spec in the TF_CONFIG ConfigMap.
+	tfClusterSpecKey = "cluster.json"
+)
+
+// isConfigMapTFConfig returns if TF_CONFIG is delivered through a ConfigMap.
+func isConfigMapTFConfig(tfjob *tfv1.TFJob) bool {
+	return tfjob.Spec.TFConfigDelivery != nil && *tfjob.Spec.TFConfigDelivery == tfv1.TFConfigDeliveryConfigMap
+}
+
+// tweakTFConfigMapListOptions selects the ConfigMaps labeled with the group
+// name of the tfjobs, which includes the TF_CONFIG ConfigMaps.
+func tweakTFConfigMapListOptions(options *metav1.ListOptions) {
+	options.LabelSelector = labels.SelectorFromSet(labels.Set{commonv1.GroupNameLabel: tfv1.GroupName}).String()
+}
+
+// genTFConfigMapName returns the name of the ConfigMap containing the cluster spec of the tfjob.
+func genTFConfigMapName(tfjob *tfv1.TFJob) string {
+	return tfjob.Name + "-tf-config"
+}
+
+// reconcileTFConfigMap creates or updates the ConfigMap containing the cluster
+// spec of the tfjob for TFConfigDeliveryConfigMap. For ClusterSpecModePodIP, the
+// ConfigMap is written once the addresses of all pods are known.
+func (tc *TFController) reconcileTFConfigMap(tfjob *tfv1.TFJob) error {
+	if !isConfigMapTFConfig(tfjob) || isSucceeded(tfjob.Status.JobStatus) || isFailed(tfjob.Status.JobStatus) {
+		return nil
+	}
+
+	var cluster ClusterSpec
+	if isPodIPClusterSpec(tfjob) {
+		pods, err := tc.GetPodsForJob(tfjob)
+		if err != nil {
+			return err
+		}
+		var ready bool
+		cluster, ready, err = genClusterSpecFromPods(tfjob, pods)
+		if err != nil || !ready {
+			return err
+		}
+	} else {
+		var err error
+		cluster, err = genClusterSpec(tfjob, tc.getClusterDomain(tfjob))
+		if err != nil {
+			return err
+		}
+	}
+	clusterJSON, err := json.Marshal(cluster)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{tfClusterSpecKey: string(clusterJSON)}
+
+	configMaps := tc.KubeClientSet.CoreV1().ConfigMaps(tfjob.Namespace)
+	configMap, err := tc.configMapLister.ConfigMaps(tfjob.Namespace).Get(genTFConfigMapName(tfjob))
+	if errors.IsNotFound(err) {
+		commonutil.LoggerForJob(tfjob).Infof("Create ConfigMap %s for TF_CONFIG", genTFConfigMapName(tfjob))
+		_, err = configMaps.Create(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            genTFConfigMapName(tfjob),
+				Namespace:       tfjob.Namespace,
+				Labels:          tc.GenLabels(tfjob.Name),
+				OwnerReferences: []metav1.OwnerReference{*tc.GenOwnerReference(tfjob)},
+			},
+			Data: data,
+		})
+		// The ConfigMap is created by an earlier sync, but not observed yet.
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(configMap, tfjob) {
+		return fmt.Errorf("configmap %s/%s already exists and is not owned by tfjob %s",
+			configMap.Namespace, configMap.Name, tfjob.Name)
+	}
+	if reflect.DeepEqual(configMap.Data, data) {
+		return nil
+	}
+	commonutil.LoggerForJob(tfjob).Infof("Update ConfigMap %s for TF_CONFIG", configMap.Name)
+	configMap = configMap.DeepCopy()
+	configMap.Data = data
+	_, err = configMaps.Update(configMap)
+	return err
+}
+
+// setTFConfigConfigMap mounts the TF_CONFIG ConfigMap into the tensorflow container,
+// sets its path in TF_CLUSTER_SPEC_FILE, and sets the task of the replica in TF_CONFIG.
+// TensorFlow does not read the cluster spec from a file, thus the training code
+// has to merge the file into TF_CONFIG, see docs/tf-config.md. For
+// ClusterSpecModePodIP, an init container waits for the ConfigMap to be written,
+// which is only once all pods have their addresses.
+func setTFConfigConfigMap(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec, rtype, index string) error {
+	tfConfigStr, err := genTaskTFConfigJSONStr(rtype, index)
+	if err != nil {
+		return err
+	}
+	// The ConfigMap may not exist yet for ClusterSpecModePodIP, which the init
+	// container waits for.
+	optional := true
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, v1.Volume{
+		Name: tfConfigVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: genTFConfigMapName(tfjob)},
+				Optional:             &optional,
+			},
+		},
+	})
+	mount := v1.VolumeMount{
+		Name:      tfConfigVolumeName,
+		MountPath: tfConfigMountPath,
+		ReadOnly:  true,
+	}
+	filePath := path.Join(tfConfigMountPath, tfClusterSpecKey)
+	containerName := tfv1.GetContainerName(&tfjob.Spec, podTemplate.Annotations)
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Name == containerName {
+			container.VolumeMounts = append(container.VolumeMounts, mount)
+			container.Env = append(container.Env, v1.EnvVar{
+				Name:  tfConfig,
+				Value: tfConfigStr,
+			}, v1.EnvVar{
+				Name:  tfClusterSpecFile,
+				Value: filePath,
+			})
+			if isPodIPClusterSpec(tfjob) {
+				podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers,
+					genTFConfigInitContainer(container, mount, filePath))
+			}
+			break
+		}
+	}
+	return nil
+}
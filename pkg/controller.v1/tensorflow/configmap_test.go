@@ -0,0 +1,155 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestReconcileTFConfigMap(t *testing.T) {
+	kubeClientSet := kubefake.NewSimpleClientset()
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, kubeInformerFactory, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	configMapIndexer := kubeInformerFactory.Core().V1().ConfigMaps().Informer().GetIndexer()
+
+	tfJob := testutil.NewTFJob(1, 1)
+	delivery := tfv1.TFConfigDeliveryConfigMap
+	tfJob.Spec.TFConfigDelivery = &delivery
+
+	if err := ctr.reconcileTFConfigMap(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the ConfigMap: %v", err)
+	}
+	// The ConfigMap which is not observed yet is not created again.
+	if err := ctr.reconcileTFConfigMap(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the ConfigMap: %v", err)
+	}
+	configMap, err := kubeClientSet.CoreV1().ConfigMaps(tfJob.Namespace).Get(genTFConfigMapName(tfJob), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the ConfigMap: %v", err)
+	}
+	expected := `{"ps":["test-tfjob-ps-0.default.svc:2222"],"worker":["test-tfjob-worker-0.default.svc:2222"]}`
+	if actual := configMap.Data[tfClusterSpecKey]; actual != expected {
+		t.Errorf("Expected cluster spec %s, got %s", expected, actual)
+	}
+	if len(configMap.OwnerReferences) != 1 || configMap.OwnerReferences[0].Name != tfJob.Name {
+		t.Errorf("Expected the ConfigMap to be owned by the tfjob, got %v", configMap.OwnerReferences)
+	}
+	// The informer only lists the ConfigMaps labeled by the operator.
+	listOptions := metav1.ListOptions{}
+	tweakTFConfigMapListOptions(&listOptions)
+	if selector, err := labels.Parse(listOptions.LabelSelector); err != nil || !selector.Matches(labels.Set(configMap.Labels)) {
+		t.Errorf("Expected the ConfigMap labeled with %v to be selected by %q", configMap.Labels, listOptions.LabelSelector)
+	}
+
+	if err := configMapIndexer.Add(configMap); err != nil {
+		t.Fatalf("Failed to add the ConfigMap to the store: %v", err)
+	}
+
+	// The cluster spec is updated in place when the workers are scaled.
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Replicas = tfv1.Int32(2)
+	if err := ctr.reconcileTFConfigMap(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the ConfigMap: %v", err)
+	}
+	configMap, err = kubeClientSet.CoreV1().ConfigMaps(tfJob.Namespace).Get(genTFConfigMapName(tfJob), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the ConfigMap: %v", err)
+	}
+	expected = `{"ps":["test-tfjob-ps-0.default.svc:2222"],"worker":["test-tfjob-worker-0.default.svc:2222","test-tfjob-worker-1.default.svc:2222"]}`
+	if actual := configMap.Data[tfClusterSpecKey]; actual != expected {
+		t.Errorf("Expected cluster spec %s, got %s", expected, actual)
+	}
+}
+
+func TestSetTFConfigConfigMap(t *testing.T) {
+	tfJob := testutil.NewTFJob(2, 0)
+	delivery := tfv1.TFConfigDeliveryConfigMap
+	tfJob.Spec.TFConfigDelivery = &delivery
+
+	ctr := &TFController{}
+	podTemplate := tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.DeepCopy()
+	if err := ctr.SetClusterSpec(tfJob, podTemplate, testutil.LabelWorker, "1"); err != nil {
+		t.Fatalf("Failed to set cluster spec: %v", err)
+	}
+
+	expectedEnv := map[string]string{
+		tfConfig:          `{"cluster":{},"task":{"type":"worker","index":1},"environment":"cloud"}`,
+		tfClusterSpecFile: "/etc/tf-config/cluster.json",
+	}
+	env := podTemplate.Spec.Containers[0].Env
+	if len(env) != len(expectedEnv) {
+		t.Fatalf("Expected %d environment variables, got %v", len(expectedEnv), env)
+	}
+	for _, e := range env {
+		if expectedEnv[e.Name] != e.Value {
+			t.Errorf("Expected %s=%s, got %s", e.Name, expectedEnv[e.Name], e.Value)
+		}
+	}
+	volumes := podTemplate.Spec.Volumes
+	if len(volumes) != 1 || volumes[0].ConfigMap == nil || volumes[0].ConfigMap.Name != genTFConfigMapName(tfJob) {
+		t.Errorf("Expected the ConfigMap volume to be added, got %v", volumes)
+	}
+	mounts := podTemplate.Spec.Containers[0].VolumeMounts
+	if len(mounts) != 1 || mounts[0] != (v1.VolumeMount{Name: tfConfigVolumeName, MountPath: tfConfigMountPath, ReadOnly: true}) {
+		t.Errorf("Expected the ConfigMap to be mounted, got %v", mounts)
+	}
+	if len(podTemplate.Spec.InitContainers) != 0 {
+		t.Errorf("Expected no init container, got %v", podTemplate.Spec.InitContainers)
+	}
+
+	// The pods wait for the ConfigMap, which is only written once all pods
+	// have their addresses.
+	mode := tfv1.ClusterSpecModePodIP
+	tfJob.Spec.ClusterSpecMode = &mode
+	podTemplate = tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.DeepCopy()
+	if err := ctr.SetClusterSpec(tfJob, podTemplate, testutil.LabelWorker, "1"); err != nil {
+		t.Fatalf("Failed to set cluster spec: %v", err)
+	}
+	initContainers := podTemplate.Spec.InitContainers
+	if len(initContainers) != 1 || initContainers[0].Name != tfConfigInitContainerName ||
+		!strings.Contains(strings.Join(initContainers[0].Command, " "), "/etc/tf-config/cluster.json") {
+		t.Errorf("Expected an init container waiting for the ConfigMap, got %v", initContainers)
+	}
+}
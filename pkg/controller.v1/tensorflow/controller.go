@@ -16,17 +16,26 @@
 package tensorflow
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1beta1"
 	"k8s.io/client-go/tools/cache"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
@@ -52,11 +61,17 @@ const (
 	// labels for pods and servers.
 	tfReplicaTypeLabel  = "replica-type"
 	tfReplicaIndexLabel = "replica-index"
-	labelGroupName      = "group-name"
+	// tfRestartAttemptLabel is the restart attempt of the tfjob the pod is
+	// created for with the "Job" restart scope.
+	tfRestartAttemptLabel = "restart-attempt"
+	labelGroupName        = "group-name"
 	// Deprecated label for backwards compatibility. Has to be removed
 	labelTFJobName = "tf-job-name"
-	// volcanoTaskSpecKey task spec key used in pod annotation when EnableGangScheduling is true
+	// volcanoTaskSpecKey task spec key used in pod annotation when gang-scheduled by Volcano
 	volcanoTaskSpecKey = "volcano.sh/task-spec"
+	// elasticReplicasOutOfRangeReason is the warning reason when the worker replicas
+	// are out of the range of the elastic policy.
+	elasticReplicasOutOfRangeReason = "ElasticReplicasOutOfRange"
 )
 
 var (
@@ -94,6 +109,39 @@ type TFController struct {
 
 	// tfJobInformerSynced returns true if the tfjob store has been synced at least once.
 	tfJobInformerSynced cache.InformerSynced
+
+	// configMapLister can list/get the TF_CONFIG ConfigMaps of the tfjobs from
+	// the shared informer's store.
+	configMapLister corelisters.ConfigMapLister
+
+	// configMapInformerSynced returns true if the ConfigMap store has been
+	// synced at least once.
+	configMapInformerSynced cache.InformerSynced
+
+	// pdbLister can list/get the PodDisruptionBudgets of the tfjobs from the
+	// shared informer's store.
+	pdbLister policylisters.PodDisruptionBudgetLister
+
+	// pdbInformerSynced returns true if the PodDisruptionBudget store has been
+	// synced at least once.
+	pdbInformerSynced cache.InformerSynced
+
+	// restartBackoffBase and restartBackoffMax bound the delay before
+	// recreating a replica restarted because of its exit code.
+	restartBackoffBase time.Duration
+	restartBackoffMax  time.Duration
+
+	// clusterDomain is the cluster domain in the cluster spec of the tfjobs
+	// without the cluster domain annotation.
+	clusterDomain string
+
+	// gangScheduler is the gang scheduling backend, or nil if gang scheduling
+	// is disabled.
+	gangScheduler gangScheduler
+
+	// restartAttempts is the last restart attempt started for the tfjobs with
+	// the "Job" restart scope, keyed by the tfjob key, until it is observed.
+	restartAttempts sync.Map
 }
 
 // NewTFController returns a new TFJob controller.
@@ -102,6 +150,8 @@ func NewTFController(
 	tfJobInformer tfjobinformersv1.TFJobInformer,
 	kubeClientSet kubeclientset.Interface,
 	volcanoClientSet volcanoclient.Interface,
+	// dynamicClient manages the PodGroups of the gang schedulers without a clientset.
+	dynamicClient dynamic.Interface,
 	tfJobClientSet tfjobclientset.Interface,
 	kubeInformerFactory kubeinformers.SharedInformerFactory,
 	// This field is not used now but we keep it since it will be used
@@ -117,14 +167,19 @@ func NewTFController(
 	log.Info("Creating TFJob controller")
 	// Create new TFController.
 	tc := &TFController{
-		tfJobClientSet: tfJobClientSet,
+		tfJobClientSet:     tfJobClientSet,
+		restartBackoffBase: option.RestartBackoffBase,
+		restartBackoffMax:  option.RestartBackoffMax,
+		clusterDomain:      option.ClusterDomain,
 	}
 
 	// Create base controller
 	log.Info("Creating Job controller")
 
+	// The PodGroups are managed by the gang scheduling backend of the TFController
+	// rather than by the job controller, which only supports Volcano.
 	jc := common.NewJobController(tc, metav1.Duration{Duration: 15 * time.Second},
-		option.EnableGangScheduling, kubeClientSet, volcanoClientSet, kubeInformerFactory, tfv1.Plural)
+		false, kubeClientSet, volcanoClientSet, kubeInformerFactory, tfv1.Plural)
 
 	// Set sync handler.
 	tc.syncHandler = tc.syncTFJob
@@ -179,8 +234,28 @@ func NewTFController(
 	jc.ServiceLister = serviceInformer.Lister()
 	jc.ServiceInformerSynced = serviceInformer.Informer().HasSynced
 
+	// Create ConfigMap informer. Only the ConfigMaps labeled by the operator are
+	// cached, rather than all ConfigMaps of the cluster.
+	configMapInformer := kubeInformerFactory.InformerFor(&v1.ConfigMap{},
+		func(client kubeclientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			return coreinformers.NewFilteredConfigMapInformer(client, option.Namespace, resyncPeriod,
+				cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, tweakTFConfigMapListOptions)
+		})
+	tc.configMapLister = corelisters.NewConfigMapLister(configMapInformer.GetIndexer())
+	tc.configMapInformerSynced = configMapInformer.HasSynced
+
+	// Create PodDisruptionBudget informer.
+	pdbInformer := kubeInformerFactory.Policy().V1beta1().PodDisruptionBudgets()
+	tc.pdbLister = pdbInformer.Lister()
+	tc.pdbInformerSynced = pdbInformer.Informer().HasSynced
+
 	tc.JobController = jc
 
+	tc.gangScheduler, err = newGangScheduler(&tc.JobController, volcanoClientSet, dynamicClient, option)
+	if err != nil {
+		log.Fatalf("Failed to create the gang scheduler: %v", err)
+	}
+
 	return tc
 }
 
@@ -199,7 +274,7 @@ func (tc *TFController) Run(threadiness int, stopCh <-chan struct{}) error {
 	log.Info("Waiting for informer caches to sync")
 
 	if ok := cache.WaitForCacheSync(stopCh, tc.tfJobInformerSynced,
-		tc.PodInformerSynced, tc.ServiceInformerSynced); !ok {
+		tc.PodInformerSynced, tc.ServiceInformerSynced, tc.configMapInformerSynced, tc.pdbInformerSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 	log.Infof("Starting %v workers", threadiness)
@@ -330,18 +405,101 @@ func (tc *TFController) syncTFJob(key string) (bool, error) {
 	// Set default for the new tfjob.
 	scheme.Scheme.Default(tfjob)
 
+	// Keep the workers of an elastic tfjob within its bounds, e.g. after
+	// the tfjob is scaled through the scale subresource.
+	clamped := clampWorkerReplicas(tfjob)
+	if clamped {
+		replicas := *tfjob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Replicas
+		msg := fmt.Sprintf("Worker replicas of TFJob %s are out of the range of its elastic policy, use %d instead.",
+			tfjob.Name, replicas)
+		logger.Warn(msg)
+		tc.Recorder.Event(tfjob, v1.EventTypeWarning, elasticReplicasOutOfRangeReason, msg)
+	}
+	workerType, ok := replicaTypeKey(sharedTFJob.Spec.TFReplicaSpecs, tfv1.TFReplicaTypeWorker)
+	if ok && tfjob.Spec.ElasticPolicy != nil && (clamped || workerType != tfv1.TFReplicaTypeWorker) {
+		if err := tc.persistWorkerReplicas(tfjob, workerType); err != nil {
+			return false, err
+		}
+		// The tfjob is synced again with the persisted spec, rather than
+		// with the stale spec from the shared informer.
+		tc.WorkQueue.AddRateLimited(key)
+		return false, nil
+	}
+	tfjob.Status.Selector = tc.workerSelector(tfjob)
+
 	var reconcileTFJobsErr error
 	if tfjobNeedsSync && tfjob.DeletionTimestamp == nil {
-		reconcileTFJobsErr = tc.ReconcileJobs(tfjob, tfjob.Spec.TFReplicaSpecs, tfjob.Status, &tfjob.Spec.RunPolicy)
+		// The conflicting PodDisruptionBudget fails the tfjob before its replicas are reconciled.
+		reconcileTFJobsErr = tc.reconcilePodDisruptionBudget(tfjob)
+		if reconcileTFJobsErr == nil && isSuspended(tfjob) {
+			reconcileTFJobsErr = tc.suspendTFJob(tfjob)
+		} else if reconcileTFJobsErr == nil {
+			reconcileTFJobsErr = tc.resumeTFJob(tfjob)
+			if reconcileTFJobsErr == nil {
+				reconcileTFJobsErr = tc.reconcileJobService(tfjob)
+			}
+			if reconcileTFJobsErr == nil {
+				reconcileTFJobsErr = tc.reconcileTFConfigMap(tfjob)
+			}
+			if reconcileTFJobsErr == nil {
+				reconcileTFJobsErr = tc.reconcilePodGroup(tfjob)
+			}
+			if reconcileTFJobsErr == nil {
+				reconcileTFJobsErr = tc.ReconcileJobs(tfjob, tfjob.Spec.TFReplicaSpecs, tfjob.Status.JobStatus, &tfjob.Spec.RunPolicy)
+			}
+		}
+	}
+
+	if reconcileTFJobsErr == nil {
+		reconcileTFJobsErr = tc.updateTFJobOnlyStatus(sharedTFJob, tfjob)
 	}
 
 	if reconcileTFJobsErr != nil {
+		if tfjob.ResourceVersion == sharedTFJob.ResourceVersion {
+			// Nothing is persisted by this sync, including a restart attempt it started.
+			tc.forgetRestartAttempt(tfjob, sharedTFJob.Status.RestartAttempt)
+		}
 		return false, reconcileTFJobsErr
 	}
 
 	return true, err
 }
 
+// persistWorkerReplicas sets the Worker replicas in the spec of the tfjob, e.g.
+// after they are clamped to its elastic policy. The Worker replica type which
+// is stored with another case, e.g. "worker", is renamed to "Worker", the only
+// key resolved by the scale subresource of the TFJob.
+func (tc *TFController) persistWorkerReplicas(tfjob *tfv1.TFJob, storedType commonv1.ReplicaType) error {
+	spec := tfjob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+	specs := map[string]interface{}{
+		string(tfv1.TFReplicaTypeWorker): map[string]interface{}{"replicas": *spec.Replicas},
+	}
+	if storedType != tfv1.TFReplicaTypeWorker {
+		specs[string(storedType)] = nil
+		specs[string(tfv1.TFReplicaTypeWorker)] = spec
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"tfReplicaSpecs": specs},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tc.tfJobClientSet.KubeflowV1().TFJobs(tfjob.Namespace).Patch(tfjob.Name, types.MergePatchType, patch)
+	return err
+}
+
+// workerSelector returns the label selector of the Worker pods of the tfjob,
+// which is the selector of the scale subresource, e.g. for the
+// HorizontalPodAutoscaler.
+func (tc *TFController) workerSelector(tfjob *tfv1.TFJob) string {
+	if _, ok := tfjob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]; !ok {
+		return ""
+	}
+	podLabels := tc.GenLabels(tfjob.Name)
+	podLabels[commonv1.ReplicaTypeLabel] = strings.ToLower(string(tfv1.TFReplicaTypeWorker))
+	return labels.SelectorFromSet(podLabels).String()
+}
+
 // satisfiedExpectations returns true if the required adds/dels for the given tfjob have been observed.
 // Add/del counts are established by the controller at sync time, and updated as controllees are observed by the controller
 // manager.
@@ -415,10 +573,68 @@ func (tc *TFController) GetDefaultContainerPortName() string {
 	return tfv1.DefaultPortName
 }
 
+// getClusterDomain returns the cluster domain in the cluster spec of the tfjob.
+func (tc *TFController) getClusterDomain(tfjob *tfv1.TFJob) string {
+	if clusterDomain, ok := tfjob.Annotations[tfv1.ClusterDomainAnnotation]; ok {
+		return clusterDomain
+	}
+	return tc.clusterDomain
+}
+
+// ReconcileServices checks and updates services for each given TFReplicaSpec.
+// No service is needed when the replicas are addressed by their pod IPs, and
+// the replicas addressed by their hostnames share the Service of the tfjob.
+func (tc *TFController) ReconcileServices(
+	job metav1.Object,
+	services []*v1.Service,
+	rtype commonv1.ReplicaType,
+	spec *commonv1.ReplicaSpec) error {
+
+	if tfJob, ok := job.(*tfv1.TFJob); ok {
+		if isPodIPClusterSpec(tfJob) || isHostnameClusterSpec(tfJob) {
+			return nil
+		}
+		spec = specWithDefaultNames(tfJob, spec)
+	}
+	return tc.JobController.ReconcileServices(job, services, rtype, spec)
+}
+
+// specWithDefaultNames returns the replica spec with its tensorflow container
+// and port renamed to the default names, which the common job controller looks
+// up for the port of the services. The given spec is returned if the tfjob does
+// not customize the names.
+func specWithDefaultNames(tfJob *tfv1.TFJob, spec *commonv1.ReplicaSpec) *commonv1.ReplicaSpec {
+	containerName := tfv1.GetContainerName(&tfJob.Spec, spec.Template.Annotations)
+	portName := tfv1.GetPortName(&tfJob.Spec, spec.Template.Annotations)
+	if containerName == tfv1.DefaultContainerName && portName == tfv1.DefaultPortName {
+		return spec
+	}
+	spec = spec.DeepCopy()
+	containers := spec.Template.Spec.Containers
+	for i := range containers {
+		if containers[i].Name != containerName {
+			// Another container may be named "tensorflow" as well.
+			if containers[i].Name == tfv1.DefaultContainerName {
+				containers[i].Name = ""
+			}
+			continue
+		}
+		containers[i].Name = tfv1.DefaultContainerName
+		for j, port := range containers[i].Ports {
+			if port.Name == portName {
+				containers[i].Ports[j].Name = tfv1.DefaultPortName
+			} else if port.Name == tfv1.DefaultPortName {
+				containers[i].Ports[j].Name = ""
+			}
+		}
+	}
+	return spec
+}
+
 func (tc *TFController) IsMasterRole(replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec, rtype commonv1.ReplicaType, index int) bool {
 
-	if ContainChieforMasterSpec(replicas) {
-		return rtype == tfv1.TFReplicaTypeChief || rtype == tfv1.TFReplicaTypeMaster
+	if ContainChieforMasterSpec(replicas) || ContainCoordinatorSpec(replicas) {
+		return tfv1.IsChieforMaster(rtype) || tfv1.IsCoordinator(rtype)
 	}
 	// else check if it is worker with index 0
 	return rtype == tfv1.TFReplicaTypeWorker && index == 0
@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
@@ -32,6 +33,7 @@ import (
 	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	tfjobfake "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned/fake"
 	tfjobinformers "github.com/kubeflow/tf-operator/pkg/client/informers/externalversions"
 	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
 )
@@ -58,7 +60,7 @@ func newTFController(
 	tfJobInformer := NewUnstructuredTFJobInformer(config, metav1.NamespaceAll, time.Hour*12)
 
 	ctr := NewTFController(tfJobInformer, kubeClientSet,
-		volcanoClientSet, tfJobClientSet, kubeInformerFactory,
+		volcanoClientSet, nil, tfJobClientSet, kubeInformerFactory,
 		tfJobInformerFactory, option)
 	ctr.PodControl = &control.FakePodControl{}
 	ctr.ServiceControl = &control.FakeServiceControl{}
@@ -228,6 +230,8 @@ func TestNormalPath(t *testing.T) {
 		ctr.tfJobInformerSynced = testutil.AlwaysReady
 		ctr.PodInformerSynced = testutil.AlwaysReady
 		ctr.ServiceInformerSynced = testutil.AlwaysReady
+		ctr.configMapInformerSynced = testutil.AlwaysReady
+		ctr.pdbInformerSynced = testutil.AlwaysReady
 		tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 
 		// Run the test logic.
@@ -250,7 +254,7 @@ func TestNormalPath(t *testing.T) {
 		testutil.SetServices(serviceIndexer, tfJob, testutil.LabelPS, tc.activePSServices, t)
 
 		//_, err = ctr.syncTFJob(testutil.GetKey(tfJob, t))
-		_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status, &tfJob.Spec.RunPolicy)
+		_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status.JobStatus, &tfJob.Spec.RunPolicy)
 
 		fakePodControl := ctr.PodControl.(*control.FakePodControl)
 		fakeServiceControl := ctr.ServiceControl.(*control.FakeServiceControl)
@@ -363,6 +367,8 @@ func TestRun(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 
 	stopCh := make(chan struct{})
 	go func() {
@@ -377,3 +383,87 @@ func TestRun(t *testing.T) {
 		t.Errorf("Failed to run: %v", err)
 	}
 }
+
+func TestSpecWithDefaultNames(t *testing.T) {
+	ctr := &TFController{}
+	ctr.JobController.Controller = ctr
+
+	tfJob := testutil.NewTFJob(1, 0)
+	containerName := "trainer"
+	tfJob.Spec.ContainerName = &containerName
+	spec := tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+	spec.Template.Annotations = map[string]string{tfv1.PortNameAnnotation: "trainer-port"}
+	spec.Template.Spec.Containers[0].Name = containerName
+	spec.Template.Spec.Containers[0].Ports = []v1.ContainerPort{{Name: "trainer-port", ContainerPort: 3333}}
+	// A sidecar with the default names is not the tensorflow container.
+	spec.Template.Spec.Containers = append([]v1.Container{{
+		Name:  tfv1.DefaultContainerName,
+		Ports: []v1.ContainerPort{{Name: tfv1.DefaultPortName, ContainerPort: 4444}},
+	}}, spec.Template.Spec.Containers...)
+
+	port, err := ctr.GetPortFromJob(specWithDefaultNames(tfJob, spec))
+	if err != nil {
+		t.Fatalf("Failed to get the port: %v", err)
+	}
+	if *port != 3333 {
+		t.Errorf("Expected port 3333, got %d", *port)
+	}
+	if spec.Template.Spec.Containers[1].Name != containerName {
+		t.Errorf("Expected the replica spec to be kept, got container %s", spec.Template.Spec.Containers[1].Name)
+	}
+}
+
+func TestPersistWorkerReplicas(t *testing.T) {
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	// The tfjob is created without the admission webhook, which keeps the
+	// lowercase Worker replica type.
+	tfJob := testutil.NewTFJob(8, 0)
+	tfJob.Spec.TFReplicaSpecs["worker"] = tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+	delete(tfJob.Spec.TFReplicaSpecs, tfv1.TFReplicaTypeWorker)
+	tfJob.Spec.ElasticPolicy = &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(2), MaxReplicas: tfv1.Int32(4)}
+	tfJob.Spec.EnableDynamicWorker = true
+	tfJobClientSet := tfjobfake.NewSimpleClientset(tfJob)
+	ctr, _, _ := newTFController(config, kubefake.NewSimpleClientset(), nil, tfJobClientSet, 0, options.ServerOption{})
+	ctr.tfJobInformerSynced = testutil.AlwaysReady
+	ctr.PodInformerSynced = testutil.AlwaysReady
+	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
+
+	unstructured, err := testutil.ConvertTFJobToUnstructured(tfJob)
+	if err != nil {
+		t.Fatalf("Failed to convert the TFJob to Unstructured: %v", err)
+	}
+	if err := ctr.tfJobInformer.GetIndexer().Add(unstructured); err != nil {
+		t.Fatalf("Failed to add tfjob to tfJobIndexer: %v", err)
+	}
+
+	key := testutil.GetKey(tfJob, t)
+	forget, err := ctr.syncTFJob(key)
+	if err != nil {
+		t.Fatalf("Failed to sync the tfjob: %v", err)
+	}
+	if forget || ctr.WorkQueue.NumRequeues(key) != 1 {
+		t.Errorf("Expected the tfjob to be requeued, got forget %v and %d requeues", forget, ctr.WorkQueue.NumRequeues(key))
+	}
+
+	persisted, err := tfJobClientSet.KubeflowV1().TFJobs(tfJob.Namespace).Get(tfJob.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the tfjob: %v", err)
+	}
+	if _, ok := persisted.Spec.TFReplicaSpecs["worker"]; ok {
+		t.Errorf("Expected the lowercase Worker replica type to be renamed, got %v", persisted.Spec.TFReplicaSpecs)
+	}
+	spec, ok := persisted.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+	if !ok || spec.Replicas == nil || *spec.Replicas != 4 {
+		t.Fatalf("Expected 4 Worker replicas, got %v", persisted.Spec.TFReplicaSpecs)
+	}
+	if len(spec.Template.Spec.Containers) != 1 {
+		t.Errorf("Expected the Worker template to be kept, got %v", spec.Template)
+	}
+}
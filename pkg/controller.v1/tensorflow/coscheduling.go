@@ -0,0 +1,206 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubeflow/common/pkg/controller.v1/common"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+const (
+	// defaultCoschedulingSchedulerName is the name of the scheduler of the
+	// scheduler-plugins, which runs the coscheduling plugin.
+	defaultCoschedulingSchedulerName = "scheduler-plugins-scheduler"
+	// coschedulingPodGroupLabel is the label key of the PodGroup of a pod
+	// scheduled by the coscheduling plugin.
+	coschedulingPodGroupLabel = "pod-group.scheduling.sigs.k8s.io"
+)
+
+// coschedulingPodGroupGVR is the resource of the PodGroups of the coscheduling plugin.
+var coschedulingPodGroupGVR = schema.GroupVersionResource{
+	Group:    "scheduling.sigs.k8s.io",
+	Version:  "v1alpha1",
+	Resource: "podgroups",
+}
+
+// coschedulingPodGroupPhases are the phases of a PodGroup of the coscheduling
+// plugin once its pods are admitted.
+var coschedulingPodGroupPhases = map[string]bool{
+	"Scheduled": true,
+	"Running":   true,
+	"Succeeded": true,
+	"Finished":  true,
+}
+
+// coschedulingScheduler gang-schedules tfjobs with the PodGroups of the
+// coscheduling plugin of the scheduler-plugins. Their API is not vendored, so
+// they are managed as unstructured objects.
+type coschedulingScheduler struct {
+	jc            *common.JobController
+	dynamicClient dynamic.Interface
+	schedulerName string
+}
+
+func newCoschedulingScheduler(jc *common.JobController, dynamicClient dynamic.Interface,
+	schedulerName string) *coschedulingScheduler {
+
+	if schedulerName == "" {
+		schedulerName = defaultCoschedulingSchedulerName
+	}
+	return &coschedulingScheduler{
+		jc:            jc,
+		dynamicClient: dynamicClient,
+		schedulerName: schedulerName,
+	}
+}
+
+func (s *coschedulingScheduler) SchedulerName() string {
+	return s.schedulerName
+}
+
+// SupportsMinTaskMembers returns false, since a PodGroup of the coscheduling
+// plugin only has the minimum members of the whole tfjob.
+func (s *coschedulingScheduler) SupportsMinTaskMembers() bool {
+	return false
+}
+
+// genPodGroupSpec returns the spec of the PodGroup, which has no queue and
+// priority class. The minimum members of the replica types are dropped, thus
+// the Workers of an elastic tfjob only count towards the total minimum members,
+// see reconcilePodGroup.
+func (s *coschedulingScheduler) genPodGroupSpec(spec podGroupSpec) map[string]interface{} {
+	pgSpec := map[string]interface{}{
+		"minMember": int64(spec.MinMember),
+	}
+	if spec.MinResources != nil && len(*spec.MinResources) > 0 {
+		minResources := map[string]interface{}{}
+		for name, quantity := range *spec.MinResources {
+			minResources[string(name)] = quantity.String()
+		}
+		pgSpec["minResources"] = minResources
+	}
+	return pgSpec
+}
+
+func (s *coschedulingScheduler) SyncPodGroup(tfjob *tfv1.TFJob, spec podGroupSpec) (bool, string, error) {
+	podGroups := s.dynamicClient.Resource(coschedulingPodGroupGVR).Namespace(tfjob.Namespace)
+	pgSpec := s.genPodGroupSpec(spec)
+
+	podGroup, err := podGroups.Get(genPodGroupName(tfjob), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		commonutil.LoggerForJob(tfjob).Infof("Create coscheduling PodGroup %s", genPodGroupName(tfjob))
+		podGroup = &unstructured.Unstructured{}
+		podGroup.SetGroupVersionKind(coschedulingPodGroupGVR.GroupVersion().WithKind("PodGroup"))
+		podGroup.SetName(genPodGroupName(tfjob))
+		podGroup.SetNamespace(tfjob.Namespace)
+		podGroup.SetLabels(s.jc.GenLabels(tfjob.Name))
+		podGroup.SetOwnerReferences([]metav1.OwnerReference{*s.jc.GenOwnerReference(tfjob)})
+		if err := unstructured.SetNestedField(podGroup.Object, pgSpec, "spec"); err != nil {
+			return false, "", err
+		}
+		podGroup, err = podGroups.Create(podGroup, metav1.CreateOptions{})
+	} else if err == nil {
+		if !coschedulingPodGroupSpecEqual(podGroup, spec) {
+			// The other fields of the spec are kept.
+			unstructured.RemoveNestedField(podGroup.Object, "spec", "minResources")
+			for field, value := range pgSpec {
+				if err := unstructured.SetNestedField(podGroup.Object, value, "spec", field); err != nil {
+					return false, "", err
+				}
+			}
+			podGroup, err = podGroups.Update(podGroup, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	phase, _, _ := unstructured.NestedString(podGroup.Object, "status", "phase")
+	if coschedulingPodGroupPhases[phase] {
+		return true, "", nil
+	}
+	return false, "", nil
+}
+
+// coschedulingPodGroupSpecEqual returns if the PodGroup already requires the
+// minimum members and resources of the spec. The quantities are parsed, since
+// the api server may store them in another format, and the other fields of the
+// PodGroup, e.g. those defaulted by the plugin, are ignored.
+func coschedulingPodGroupSpecEqual(podGroup *unstructured.Unstructured, spec podGroupSpec) bool {
+	minMember, found, err := unstructured.NestedFieldNoCopy(podGroup.Object, "spec", "minMember")
+	if err != nil || !found {
+		return false
+	}
+	switch m := minMember.(type) {
+	case int64:
+		if m != int64(spec.MinMember) {
+			return false
+		}
+	case float64:
+		if m != float64(spec.MinMember) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	current, _, err := unstructured.NestedStringMap(podGroup.Object, "spec", "minResources")
+	if err != nil {
+		return false
+	}
+	var minResources v1.ResourceList
+	if spec.MinResources != nil {
+		minResources = *spec.MinResources
+	}
+	if len(current) != len(minResources) {
+		return false
+	}
+	for name, quantity := range minResources {
+		value, ok := current[string(name)]
+		if !ok {
+			return false
+		}
+		parsed, err := resource.ParseQuantity(value)
+		if err != nil || parsed.Cmp(quantity) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *coschedulingScheduler) DeletePodGroup(tfjob *tfv1.TFJob) error {
+	err := s.dynamicClient.Resource(coschedulingPodGroupGVR).Namespace(tfjob.Namespace).
+		Delete(genPodGroupName(tfjob), &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *coschedulingScheduler) DecoratePodTemplate(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec, rt string) {
+	if podTemplate.Labels == nil {
+		podTemplate.Labels = map[string]string{}
+	}
+	podTemplate.Labels[coschedulingPodGroupLabel] = genPodGroupName(tfjob)
+}
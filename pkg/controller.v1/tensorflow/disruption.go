@@ -0,0 +1,213 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/common/pkg/controller.v1/control"
+	"github.com/kubeflow/common/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// nodeLostReason is the reason of a pod whose node stopped reporting its status.
+	nodeLostReason = "NodeLost"
+	// disruptionTargetCondition is the pod condition added when the pod is
+	// deleted because of a disruption, e.g. preemption or node shutdown.
+	disruptionTargetCondition v1.PodConditionType = "DisruptionTarget"
+	// nodeLostTimeout is how long a pod stays in the Unknown phase before its
+	// node is considered lost, unless the pod is already evicted from the node
+	// because of the node-unreachable taint.
+	nodeLostTimeout = 5 * time.Minute
+)
+
+var (
+	// disruptedPodReasons are the reasons of the failed pods which were killed
+	// by the infrastructure rather than failed because of the training.
+	disruptedPodReasons = map[string]bool{
+		// The kubelet evicts pods under node pressure.
+		"Evicted": true,
+		// The kubelet preempts pods for critical pods.
+		"Preempting": true,
+		"Preempted":  true,
+		// The kubelet terminates pods on graceful node shutdown.
+		"Shutdown":     true,
+		"NodeShutdown": true,
+		"Terminated":   true,
+	}
+
+	tfJobsDisruptedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tf_operator_jobs_replicas_disrupted_total",
+			Help: "Counts number of TF job replicas recreated because of evictions, preemptions or node loss",
+		},
+		[]string{"job_namespace", "reason"},
+	)
+)
+
+// podDisruptionReason returns the reason why the pod was killed by the
+// infrastructure, e.g. "Evicted", or an empty string if it was not.
+// The pods on lost nodes stay in the Unknown phase, see nodeLostRemaining.
+func podDisruptionReason(pod *v1.Pod) string {
+	// The pods on lost nodes are never deleted gracefully, thus they are
+	// recreated even if they are terminating.
+	if pod.Status.Phase == v1.PodUnknown {
+		return nodeLostReason
+	}
+	if pod.DeletionTimestamp != nil {
+		return ""
+	}
+	switch pod.Status.Phase {
+	case v1.PodFailed:
+		if disruptedPodReasons[pod.Status.Reason] {
+			return pod.Status.Reason
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == disruptionTargetCondition && condition.Status == v1.ConditionTrue {
+				if condition.Reason != "" {
+					return condition.Reason
+				}
+				return string(disruptionTargetCondition)
+			}
+		}
+	}
+	return ""
+}
+
+// handleDisruption recreates the replica of the pod if it was killed by the
+// infrastructure, regardless of its restart policy and exit code. Such restarts
+// are counted separately and are not delayed by the restart backoff.
+// It returns true if the pod is deleted.
+func (tc *TFController) handleDisruption(tfjob *tfv1.TFJob, jobStatus *commonv1.JobStatus,
+	rtype commonv1.ReplicaType, index int, pod *v1.Pod, jobPods []*v1.Pod) (bool, error) {
+
+	reason := podDisruptionReason(pod)
+	if reason == "" {
+		return false, nil
+	}
+	// The node may come back, thus the replica is only recreated once the node
+	// is considered lost.
+	if reason == nodeLostReason {
+		if remaining := nodeLostRemaining(pod); remaining > 0 {
+			commonutil.LoggerForJob(tfjob).Infof("Pod %s/%s is in the Unknown phase, recreate it after %v",
+				pod.Namespace, pod.Name, remaining)
+			key, err := KeyFunc(tfjob)
+			if err != nil {
+				return false, err
+			}
+			tc.WorkQueue.AddAfter(key, remaining)
+			return false, nil
+		}
+	}
+	msg := fmt.Sprintf("TFJob %s is recreating %s replica %d because pod %s/%s was disrupted (%s).",
+		tfjob.Name, rtype, index, pod.Namespace, pod.Name, reason)
+	if isJobRestartScope(tfjob) {
+		if err := tc.restartAllReplicas(tfjob, jobPods); err != nil {
+			return false, err
+		}
+		msg = fmt.Sprintf("TFJob %s is restarting all replicas as attempt %d because pod %s/%s was disrupted (%s).",
+			tfjob.Name, tfjob.Status.RestartAttempt, pod.Namespace, pod.Name, reason)
+	} else if err := tc.deletePod(tfjob, pod); err != nil {
+		return false, err
+	}
+	getOrAddReplicaIndexStatus(&tfjob.Status, rtype, index).Disruptions++
+
+	tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobDisruptedReason, msg)
+	if err := commonutil.UpdateJobConditions(jobStatus, commonv1.JobRestarting, tfJobDisruptedReason, msg); err != nil {
+		commonutil.LoggerForJob(tfjob).Infof("Append tfjob condition error: %v", err)
+		return true, err
+	}
+	tfJobsDisruptedCount.WithLabelValues(tfjob.Namespace, reason).Inc()
+	return true, nil
+}
+
+// nodeLostRemaining returns how long the pod in the Unknown phase has to stay
+// in it before its node is considered lost, i.e. nodeLostTimeout after the pod
+// stopped being ready, or 0 if the pod is already evicted from the node.
+func nodeLostRemaining(pod *v1.Pod) time.Duration {
+	if pod.DeletionTimestamp != nil {
+		return 0
+	}
+	since := pod.CreationTimestamp.Time
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady && condition.Status != v1.ConditionTrue {
+			since = condition.LastTransitionTime.Time
+		}
+	}
+	if remaining := nodeLostTimeout - time.Since(since); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// deleteReplicaPods deletes the pods of the tfjob, and expects their deletions,
+// which are observed per replica type before the pods are reconciled again.
+func (tc *TFController) deleteReplicaPods(tfjob *tfv1.TFJob, pods []*v1.Pod) error {
+	tfjobKey, err := KeyFunc(tfjob)
+	if err != nil {
+		return err
+	}
+	podsByType := map[string][]*v1.Pod{}
+	for _, pod := range pods {
+		// The terminating pods on lost nodes are deleted again immediately.
+		if pod.DeletionTimestamp != nil && pod.Status.Phase != v1.PodUnknown {
+			continue
+		}
+		rt := pod.Labels[commonv1.ReplicaTypeLabel]
+		podsByType[rt] = append(podsByType[rt], pod)
+	}
+	for rt, rtPods := range podsByType {
+		expectationPodsKey := expectation.GenExpectationPodsKey(tfjobKey, rt)
+		if err := tc.Expectations.ExpectDeletions(expectationPodsKey, len(rtPods)); err != nil {
+			return err
+		}
+		for i, pod := range rtPods {
+			if err := tc.deletePod(tfjob, pod); err != nil {
+				// The pods which are not deleted will not be observed.
+				tc.Expectations.LowerExpectations(expectationPodsKey, 0, len(rtPods)-i)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deletePod deletes the pod of a replica. The pods on lost nodes are deleted
+// immediately, as their kubelet never confirms the graceful deletion, and the
+// replica could not be recreated with the same name meanwhile.
+func (tc *TFController) deletePod(tfjob *tfv1.TFJob, pod *v1.Pod) error {
+	if pod.Status.Phase != v1.PodUnknown {
+		return tc.PodControl.DeletePod(pod.Namespace, pod.Name, tfjob)
+	}
+	commonutil.LoggerForJob(tfjob).Infof("Force deleting pod %s/%s on a lost node", pod.Namespace, pod.Name)
+	var gracePeriod int64
+	err := tc.KubeClientSet.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+	if err != nil && !errors.IsNotFound(err) {
+		tc.Recorder.Eventf(tfjob, v1.EventTypeWarning, control.FailedDeletePodReason, "Error deleting: %v", err)
+		return err
+	}
+	tc.Recorder.Eventf(tfjob, v1.EventTypeNormal, control.SuccessfulDeletePodReason, "Force deleted pod: %v", pod.Name)
+	return nil
+}
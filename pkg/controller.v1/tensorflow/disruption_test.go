@@ -0,0 +1,222 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/common/pkg/controller.v1/control"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestPodDisruptionReason(t *testing.T) {
+	testCases := map[string]struct {
+		status   v1.PodStatus
+		expected string
+	}{
+		"running": {
+			status:   v1.PodStatus{Phase: v1.PodRunning},
+			expected: "",
+		},
+		"failed": {
+			status:   v1.PodStatus{Phase: v1.PodFailed, Reason: "Error"},
+			expected: "",
+		},
+		"evicted": {
+			status:   v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"},
+			expected: "Evicted",
+		},
+		"disruption target": {
+			status: v1.PodStatus{
+				Phase: v1.PodFailed,
+				Conditions: []v1.PodCondition{{
+					Type: disruptionTargetCondition, Status: v1.ConditionTrue, Reason: "PreemptionByScheduler",
+				}},
+			},
+			expected: "PreemptionByScheduler",
+		},
+		"node lost": {
+			status:   v1.PodStatus{Phase: v1.PodUnknown},
+			expected: nodeLostReason,
+		},
+	}
+	for name, tc := range testCases {
+		pod := &v1.Pod{Status: tc.status}
+		if actual := podDisruptionReason(pod); actual != tc.expected {
+			t.Errorf("%s: expected reason %q, got %q", name, tc.expected, actual)
+		}
+	}
+}
+
+func TestEvictedPodIsRecreated(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, _, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+
+	tfJob := testutil.NewTFJob(1, 0)
+	rtype := tfv1.TFReplicaTypeWorker
+	spec := tfJob.Spec.TFReplicaSpecs[rtype]
+	spec.RestartPolicy = commonv1.RestartPolicyNever
+
+	pods := testutil.NewPodList(1, v1.PodFailed, tfJob, testutil.LabelWorker, 0)
+	pods[0].Status.Reason = "Evicted"
+	err := ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods, rtype, spec, tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.DeletePodName) != 1 || fakePodControl.DeletePodName[0] != pods[0].Name {
+		t.Errorf("Expected pod %s to be deleted, got %v", pods[0].Name, fakePodControl.DeletePodName)
+	}
+	if failed := tfJob.Status.ReplicaStatuses[rtype].Failed; failed != 0 {
+		t.Errorf("Expected the evicted pod not to be counted as failed, got %d", failed)
+	}
+	status := getReplicaIndexStatus(&tfJob.Status, rtype, 0)
+	if status == nil || status.Disruptions != 1 || status.Restarts != 0 || status.LastRestartTime != nil {
+		t.Errorf("Expected the disruption to be counted apart from the restarts, got %+v", status)
+	}
+	if !hasCondition(tfJob.Status.JobStatus, commonv1.JobRestarting) {
+		t.Errorf("Expected the tfjob to be restarting, got %v", tfJob.Status.Conditions)
+	}
+}
+
+func TestNodeLostPodIsRecreated(t *testing.T) {
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+
+	testCases := map[string]struct {
+		notReadySince time.Duration
+		terminating   bool
+		recreated     bool
+	}{
+		"node may come back": {
+			notReadySince: time.Minute,
+		},
+		"node lost": {
+			notReadySince: time.Hour,
+			recreated:     true,
+		},
+		"evicted from the unreachable node": {
+			notReadySince: time.Minute,
+			terminating:   true,
+			recreated:     true,
+		},
+	}
+	for name, tc := range testCases {
+		tfJob := testutil.NewTFJob(1, 0)
+		rtype := tfv1.TFReplicaTypeWorker
+		spec := tfJob.Spec.TFReplicaSpecs[rtype]
+		spec.RestartPolicy = commonv1.RestartPolicyNever
+
+		pods := testutil.NewPodList(1, v1.PodUnknown, tfJob, testutil.LabelWorker, 0)
+		pods[0].Status.Conditions = []v1.PodCondition{{
+			Type:               v1.PodReady,
+			Status:             v1.ConditionUnknown,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-tc.notReadySince)),
+		}}
+		if tc.terminating {
+			now := metav1.Now()
+			pods[0].DeletionTimestamp = &now
+		}
+		kubeClientSet := kubefake.NewSimpleClientset(pods[0])
+		ctr, _, _ := newTFController(config, kubeClientSet,
+			volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+		fakePodControl := &control.FakePodControl{}
+		ctr.PodControl = fakePodControl
+
+		err := ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods, rtype, spec, tfJob.Spec.TFReplicaSpecs)
+		if err != nil {
+			t.Fatalf("%s: failed to reconcile pods: %v", name, err)
+		}
+		// The pod is force deleted rather than deleted gracefully.
+		if len(fakePodControl.DeletePodName) != 0 {
+			t.Errorf("%s: expected no graceful deletion, got %v", name, fakePodControl.DeletePodName)
+		}
+		remaining, err := kubeClientSet.CoreV1().Pods(tfJob.Namespace).List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("%s: failed to list pods: %v", name, err)
+		}
+		if deleted := len(remaining.Items) == 0; deleted != tc.recreated {
+			t.Errorf("%s: expected the pod to be deleted %v, got %v", name, tc.recreated, deleted)
+		}
+		if !tc.recreated {
+			continue
+		}
+
+		// The replacement is created once the deletion is observed.
+		err = ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, nil, rtype, spec, tfJob.Spec.TFReplicaSpecs)
+		if err != nil {
+			t.Fatalf("%s: failed to reconcile pods: %v", name, err)
+		}
+		if len(fakePodControl.Templates) != 1 {
+			t.Errorf("%s: expected the replacement pod to be created, got %d pods", name, len(fakePodControl.Templates))
+		}
+		if status := getReplicaIndexStatus(&tfJob.Status, rtype, 0); status == nil || status.Disruptions != 1 {
+			t.Errorf("%s: expected the disruption to be counted, got %+v", name, status)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/common/pkg/controller.v1/common"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	"github.com/kubeflow/common/pkg/util/k8sutil"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+// podGroupSpec is the gang scheduling requirement of a tfjob, which each gang
+// scheduler translates into its own PodGroup.
+type podGroupSpec struct {
+	// MinMember is the number of replicas which are scheduled together.
+	MinMember int32
+	// Queue is the queue of the PodGroup, if the gang scheduler supports queues.
+	Queue string
+	// PriorityClass is the priority class of the PodGroup.
+	PriorityClass string
+	// MinResources is the total resources of the MinMember replicas.
+	MinResources *v1.ResourceList
+	// MinTaskMembers is the minimum number of replicas of each replica type,
+	// keyed by the lower-case replica type. It is empty unless the minimum
+	// members are set per replica type.
+	MinTaskMembers map[string]int32
+}
+
+// gangScheduler is a gang scheduling backend, which schedules all replicas of
+// a tfjob together through a PodGroup named after the tfjob.
+type gangScheduler interface {
+	// SchedulerName returns the scheduler name of the pods of the tfjobs.
+	SchedulerName() string
+	// SupportsMinTaskMembers returns if the PodGroups can require the minimum
+	// members of each replica type, rather than only of the whole tfjob.
+	SupportsMinTaskMembers() bool
+	// SyncPodGroup creates or updates the PodGroup of the tfjob, and returns if
+	// it has been admitted by the scheduler, or a message explaining why not.
+	SyncPodGroup(tfjob *tfv1.TFJob, spec podGroupSpec) (bool, string, error)
+	// DeletePodGroup deletes the PodGroup of the tfjob if it exists.
+	DeletePodGroup(tfjob *tfv1.TFJob) error
+	// DecoratePodTemplate adds the annotations and labels assigning the pod of
+	// the replica type to the PodGroup of the tfjob.
+	DecoratePodTemplate(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec, rt string)
+}
+
+// newGangScheduler returns the gang scheduling backend selected by the options,
+// or nil if gang scheduling is disabled.
+func newGangScheduler(jc *common.JobController, volcanoClientSet volcanoclient.Interface,
+	dynamicClient dynamic.Interface, option options.ServerOption) (gangScheduler, error) {
+
+	if !option.EnableGangScheduling {
+		return nil, nil
+	}
+	switch option.GangSchedulingBackend {
+	case options.GangSchedulingBackendVolcano, "":
+		return newVolcanoScheduler(jc, volcanoClientSet, option.GangSchedulerName), nil
+	case options.GangSchedulingBackendCoscheduling:
+		return newCoschedulingScheduler(jc, dynamicClient, option.GangSchedulerName), nil
+	}
+	return nil, fmt.Errorf("unsupported gang scheduling backend %q", option.GangSchedulingBackend)
+}
+
+// genPodGroupName returns the name of the PodGroup of the tfjob.
+func genPodGroupName(tfjob *tfv1.TFJob) string {
+	return tfjob.Name
+}
+
+// genPodGroupSpec returns the gang scheduling requirement of the tfjob. All
+// replicas are scheduled together unless the scheduling policy or the minimum
+// members of the replica types say otherwise.
+func (tc *TFController) genPodGroupSpec(tfjob *tfv1.TFJob) podGroupSpec {
+	spec := podGroupSpec{
+		MinMember: k8sutil.GetTotalReplicas(tfjob.Spec.TFReplicaSpecs),
+	}
+	policy := tfjob.Spec.RunPolicy.SchedulingPolicy
+	if policy != nil {
+		spec.Queue = policy.Queue
+		spec.PriorityClass = policy.PriorityClass
+		spec.MinResources = policy.MinResources
+	}
+	if policy != nil && policy.MinAvailable != nil {
+		spec.MinMember = *policy.MinAvailable
+	} else if len(tfjob.Spec.MinMembers) > 0 || tfjob.Spec.ElasticPolicy != nil {
+		minMembers := replicaMinMembers(tfjob)
+		spec.MinMember = 0
+		spec.MinTaskMembers = map[string]int32{}
+		for rtype, minMember := range minMembers {
+			spec.MinMember += minMember
+			spec.MinTaskMembers[strings.ToLower(string(rtype))] = minMember
+		}
+		if spec.MinResources == nil {
+			spec.MinResources = calcMinMembersResources(minMembers, tfjob.Spec.TFReplicaSpecs)
+		}
+	}
+	if spec.MinResources == nil {
+		spec.MinResources = tc.calcPodGroupMinResources(spec.MinMember, tfjob.Spec.TFReplicaSpecs)
+	}
+	return spec
+}
+
+// replicaMinMembers returns the minimum number of replicas of each replica
+// type which are gang-scheduled together. All replicas are members unless the
+// minimum members of the type are set, except the Workers of an elastic tfjob,
+// which can start with the minimum replicas of the elastic policy.
+func replicaMinMembers(tfjob *tfv1.TFJob) map[commonv1.ReplicaType]int32 {
+	minMembers := map[commonv1.ReplicaType]int32{}
+	for rtype, spec := range tfjob.Spec.TFReplicaSpecs {
+		if spec == nil || spec.Replicas == nil {
+			continue
+		}
+		minMember := *spec.Replicas
+		if m, ok := tfjob.Spec.MinMembers[rtype]; ok {
+			minMember = m
+		} else if tfv1.IsWorker(rtype) && tfjob.Spec.ElasticPolicy != nil {
+			minMember = 1
+			if tfjob.Spec.ElasticPolicy.MinReplicas != nil {
+				minMember = *tfjob.Spec.ElasticPolicy.MinReplicas
+			}
+		}
+		// The workers of an elastic tfjob may be scaled below the minimum.
+		if minMember > *spec.Replicas {
+			minMember = *spec.Replicas
+		}
+		minMembers[rtype] = minMember
+	}
+	return minMembers
+}
+
+// calcMinMembersResources returns the resources of the minimum members of the
+// replica types.
+func calcMinMembersResources(minMembers map[commonv1.ReplicaType]int32,
+	replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) *v1.ResourceList {
+
+	minResources := v1.ResourceList{}
+	for rtype, minMember := range minMembers {
+		for i := int32(0); i < minMember; i++ {
+			for _, c := range replicas[rtype].Template.Spec.Containers {
+				common.AddResourceList(minResources, c.Resources.Requests, c.Resources.Limits)
+			}
+		}
+	}
+	return &minResources
+}
+
+// calcPodGroupMinResources returns the resources of the minMember replicas of
+// the highest priority, which are scheduled first.
+func (tc *TFController) calcPodGroupMinResources(minMember int32,
+	replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) *v1.ResourceList {
+
+	type replicaPriority struct {
+		rtype    commonv1.ReplicaType
+		priority int32
+		spec     *commonv1.ReplicaSpec
+	}
+	var priorities []replicaPriority
+	for rtype, spec := range replicas {
+		if spec == nil || spec.Replicas == nil {
+			continue
+		}
+		rp := replicaPriority{rtype: rtype, spec: spec}
+		if pc := spec.Template.Spec.PriorityClassName; pc != "" && tc.PriorityClassLister != nil {
+			priorityClass, err := tc.PriorityClassLister.Get(pc)
+			if err != nil {
+				log.Warnf("Ignore priority class %s of replica type %s: %v", pc, rtype, err)
+			} else {
+				rp.priority = priorityClass.Value
+			}
+		}
+		priorities = append(priorities, rp)
+	}
+	// The order of the replica types of the same priority is stable.
+	sort.Slice(priorities, func(i, j int) bool {
+		if priorities[i].priority != priorities[j].priority {
+			return priorities[i].priority > priorities[j].priority
+		}
+		return priorities[i].rtype < priorities[j].rtype
+	})
+
+	minResources := v1.ResourceList{}
+	members := int32(0)
+	for _, rp := range priorities {
+		for i := int32(0); i < *rp.spec.Replicas && members < minMember; i++ {
+			members++
+			for _, c := range rp.spec.Template.Spec.Containers {
+				common.AddResourceList(minResources, c.Resources.Requests, c.Resources.Limits)
+			}
+		}
+	}
+	return &minResources
+}
+
+// reconcilePodGroup creates the PodGroup of an active tfjob, records whether it
+// has been admitted in the status of the tfjob, and deletes the PodGroup once
+// the tfjob has finished.
+func (tc *TFController) reconcilePodGroup(tfjob *tfv1.TFJob) error {
+	if tc.gangScheduler == nil {
+		return nil
+	}
+	logger := commonutil.LoggerForJob(tfjob)
+
+	if isSucceeded(tfjob.Status.JobStatus) || isFailed(tfjob.Status.JobStatus) {
+		if err := tc.gangScheduler.DeletePodGroup(tfjob); err != nil {
+			tc.Recorder.Eventf(tfjob, v1.EventTypeWarning, "FailedDeletePodGroup", "Error deleting: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	// Without the minimum members of each replica type, the gang scheduler
+	// could admit the tfjob without e.g. its PS, so it never starts.
+	if len(tfjob.Spec.MinMembers) > 0 && !tc.gangScheduler.SupportsMinTaskMembers() {
+		msg := fmt.Sprintf("TFJob %s has failed because MinMembers are not supported by %s.",
+			tfjob.Name, tc.gangScheduler.SchedulerName())
+		tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobMinMembersUnsupportedReason, msg)
+		if err := commonutil.UpdateJobConditions(&tfjob.Status.JobStatus, commonv1.JobFailed, tfJobMinMembersUnsupportedReason, msg); err != nil {
+			logger.Infof("Append tfjob condition error: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	spec := tc.genPodGroupSpec(tfjob)
+	// The minimum Workers of an elastic tfjob are reported once, since the
+	// tfjob can still start with the total minimum members.
+	if len(spec.MinTaskMembers) > 0 && !tc.gangScheduler.SupportsMinTaskMembers() && tfjob.Status.PodGroupStatus == nil {
+		msg := fmt.Sprintf("TFJob %s only requires %d members in total, since %s does not support the minimum members of each replica type.",
+			tfjob.Name, spec.MinMember, tc.gangScheduler.SchedulerName())
+		tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobMinMembersUnsupportedReason, msg)
+	}
+	admitted, message, err := tc.gangScheduler.SyncPodGroup(tfjob, spec)
+	if err != nil {
+		logger.Warnf("Sync PodGroup %s: %v", genPodGroupName(tfjob), err)
+		return err
+	}
+	tc.updatePodGroupStatus(tfjob, admitted, message)
+	return nil
+}
+
+// updatePodGroupStatus records in the status of the tfjob whether its PodGroup
+// is admitted, with the message of the scheduler while it is pending, and
+// reports it with the PodGroupAdmitted condition and events.
+func (tc *TFController) updatePodGroupStatus(tfjob *tfv1.TFJob, admitted bool, message string) {
+	status := &tfv1.PodGroupStatus{
+		Name:     genPodGroupName(tfjob),
+		Admitted: admitted,
+		Message:  message,
+	}
+	previous := tfjob.Status.PodGroupStatus
+	if reflect.DeepEqual(previous, status) {
+		return
+	}
+	tfjob.Status.PodGroupStatus = status
+	if admitted {
+		msg := fmt.Sprintf("PodGroup %s of TFJob %s/%s is admitted by %s.",
+			status.Name, tfjob.Namespace, tfjob.Name, tc.gangScheduler.SchedulerName())
+		tc.Recorder.Event(tfjob, v1.EventTypeNormal, tfJobPodGroupAdmittedReason, msg)
+		setProgressCondition(&tfjob.Status.JobStatus, tfv1.JobPodGroupAdmitted, v1.ConditionTrue, tfJobPodGroupAdmittedReason, msg)
+		return
+	}
+	msg := fmt.Sprintf("PodGroup %s of TFJob %s/%s is waiting to be admitted by %s.",
+		status.Name, tfjob.Namespace, tfjob.Name, tc.gangScheduler.SchedulerName())
+	if message != "" {
+		msg = fmt.Sprintf("%s %s", msg, message)
+	}
+	if previous == nil || previous.Admitted {
+		tc.Recorder.Event(tfjob, v1.EventTypeNormal, tfJobPodGroupPendingReason, msg)
+	}
+	setProgressCondition(&tfjob.Status.JobStatus, tfv1.JobPodGroupAdmitted, v1.ConditionFalse, tfJobPodGroupPendingReason, msg)
+}
+
+// setPodGangScheduling sets the scheduler of the pod template to the gang
+// scheduler, unless another scheduler is set for any replica of the tfjob, and
+// assigns the pod to the PodGroup of the tfjob.
+func (tc *TFController) setPodGangScheduling(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec, rt string) {
+	if tc.gangScheduler == nil {
+		return
+	}
+	if tc.isNonGangSchedulerSet(tfjob.Spec.TFReplicaSpecs) {
+		errMsg := "Another scheduler is specified when gang-scheduling is enabled and it will not be overwritten"
+		commonutil.LoggerForReplica(tfjob, rt).Warning(errMsg)
+		tc.Recorder.Event(tfjob, v1.EventTypeWarning, podTemplateSchedulerNameReason, errMsg)
+	} else {
+		podTemplate.Spec.SchedulerName = tc.gangScheduler.SchedulerName()
+	}
+	tc.gangScheduler.DecoratePodTemplate(tfjob, podTemplate, rt)
+}
+
+// isNonGangSchedulerSet returns if a scheduler other than the gang scheduler
+// is set for any replica.
+func (tc *TFController) isNonGangSchedulerSet(replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) bool {
+	for _, spec := range replicas {
+		if spec.Template.Spec.SchedulerName != "" && spec.Template.Spec.SchedulerName != tc.gangScheduler.SchedulerName() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,399 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+// newGangTFController returns a controller gang-scheduling tfjobs with the
+// PodGroups of the fake clients.
+func newGangTFController(t *testing.T, backend string) (*TFController, *volcanofake.Clientset, *dynamicfake.FakeDynamicClient) {
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	volcanoClientSet := volcanofake.NewSimpleClientset()
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	ctr, _, _ := newTFController(config, kubefake.NewSimpleClientset(),
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+
+	var err error
+	ctr.gangScheduler, err = newGangScheduler(&ctr.JobController, volcanoClientSet, dynamicClient,
+		options.ServerOption{EnableGangScheduling: true, GangSchedulingBackend: backend})
+	if err != nil {
+		t.Fatalf("Failed to create the gang scheduler: %v", err)
+	}
+	return ctr, volcanoClientSet, dynamicClient
+}
+
+func TestNewGangScheduler(t *testing.T) {
+	testCases := []struct {
+		option        options.ServerOption
+		schedulerName string
+		expectedErr   bool
+	}{
+		{options.ServerOption{}, "", false},
+		{options.ServerOption{EnableGangScheduling: true}, defaultVolcanoSchedulerName, false},
+		{options.ServerOption{EnableGangScheduling: true, GangSchedulingBackend: options.GangSchedulingBackendCoscheduling},
+			defaultCoschedulingSchedulerName, false},
+		{options.ServerOption{EnableGangScheduling: true, GangSchedulingBackend: options.GangSchedulingBackendCoscheduling,
+			GangSchedulerName: "my-scheduler"}, "my-scheduler", false},
+		{options.ServerOption{EnableGangScheduling: true, GangSchedulingBackend: "kube-batch"}, "", true},
+	}
+	for _, tc := range testCases {
+		scheduler, err := newGangScheduler(nil, nil, nil, tc.option)
+		if (err != nil) != tc.expectedErr {
+			t.Errorf("Option %+v: expected error %v, got %v", tc.option, tc.expectedErr, err)
+			continue
+		}
+		schedulerName := ""
+		if scheduler != nil {
+			schedulerName = scheduler.SchedulerName()
+		}
+		if schedulerName != tc.schedulerName {
+			t.Errorf("Option %+v: expected scheduler %q, got %q", tc.option, tc.schedulerName, schedulerName)
+		}
+	}
+}
+
+func TestVolcanoPodGroup(t *testing.T) {
+	ctr, volcanoClientSet, _ := newGangTFController(t, options.GangSchedulingBackendVolcano)
+	podGroups := volcanoClientSet.SchedulingV1beta1().PodGroups(metav1.NamespaceDefault)
+	tfJob := testutil.NewTFJob(2, 1)
+
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	podGroup, err := podGroups.Get(tfJob.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the PodGroup: %v", err)
+	}
+	if podGroup.Spec.MinMember != 3 {
+		t.Errorf("Expected 3 members of the PodGroup, got %d", podGroup.Spec.MinMember)
+	}
+	if status := tfJob.Status.PodGroupStatus; status == nil || status.Name != tfJob.Name || status.Admitted {
+		t.Errorf("Expected the PodGroup not to be admitted, got %+v", status)
+	}
+
+	// The tfjob is admitted once Volcano has enqueued the PodGroup.
+	podGroup.Status.Phase = batchv1beta1.PodGroupInqueue
+	if _, err := podGroups.Update(podGroup); err != nil {
+		t.Fatalf("Failed to update the PodGroup: %v", err)
+	}
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	if status := tfJob.Status.PodGroupStatus; status == nil || !status.Admitted {
+		t.Errorf("Expected the PodGroup to be admitted, got %+v", status)
+	}
+	if !hasCondition(tfJob.Status.JobStatus, tfv1.JobPodGroupAdmitted) {
+		t.Errorf("Expected the PodGroupAdmitted condition, got %v", tfJob.Status.Conditions)
+	}
+
+	podTemplate := tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.DeepCopy()
+	ctr.setPodGangScheduling(tfJob, podTemplate, "worker")
+	if podTemplate.Spec.SchedulerName != defaultVolcanoSchedulerName {
+		t.Errorf("Expected the pod to be scheduled by %s, got %q", defaultVolcanoSchedulerName, podTemplate.Spec.SchedulerName)
+	}
+	if podTemplate.Annotations[volcanoPodGroupAnnotation] != tfJob.Name || podTemplate.Annotations[volcanoTaskSpecKey] != "worker" {
+		t.Errorf("Expected the pod to be annotated with its PodGroup and task, got %v", podTemplate.Annotations)
+	}
+
+	// The PodGroup is deleted once the tfjob finishes.
+	tfJob.Status.Conditions = []commonv1.JobCondition{{Type: commonv1.JobSucceeded, Status: v1.ConditionTrue}}
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	if _, err := podGroups.Get(tfJob.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the PodGroup to be deleted, got %v", err)
+	}
+}
+
+func TestCoschedulingPodGroup(t *testing.T) {
+	ctr, _, dynamicClient := newGangTFController(t, options.GangSchedulingBackendCoscheduling)
+	podGroups := dynamicClient.Resource(coschedulingPodGroupGVR).Namespace(metav1.NamespaceDefault)
+	tfJob := testutil.NewTFJob(2, 1)
+	minAvailable := int32(2)
+	tfJob.Spec.RunPolicy.SchedulingPolicy = &commonv1.SchedulingPolicy{MinAvailable: &minAvailable}
+	for _, spec := range tfJob.Spec.TFReplicaSpecs {
+		spec.Template.Spec.Containers[0].Resources.Requests = v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}
+	}
+
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	podGroup, err := podGroups.Get(tfJob.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the PodGroup: %v", err)
+	}
+	if minMember, _, _ := unstructured.NestedInt64(podGroup.Object, "spec", "minMember"); minMember != 2 {
+		t.Errorf("Expected 2 members of the PodGroup, got %d", minMember)
+	}
+	if status := tfJob.Status.PodGroupStatus; status == nil || status.Name != tfJob.Name || status.Admitted {
+		t.Errorf("Expected the PodGroup not to be admitted, got %+v", status)
+	}
+
+	// The PodGroup is not updated for the fields it is defaulted with or the
+	// format of its quantities.
+	if err := unstructured.SetNestedField(podGroup.Object, int64(60), "spec", "scheduleTimeoutSeconds"); err != nil {
+		t.Fatalf("Failed to set the schedule timeout of the PodGroup: %v", err)
+	}
+	if err := unstructured.SetNestedField(podGroup.Object, "2000m", "spec", "minResources", "cpu"); err != nil {
+		t.Fatalf("Failed to set the minimum resources of the PodGroup: %v", err)
+	}
+	if podGroup, err = podGroups.Update(podGroup, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update the PodGroup: %v", err)
+	}
+	dynamicClient.ClearActions()
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	for _, action := range dynamicClient.Actions() {
+		if action.GetVerb() != "get" {
+			t.Errorf("Expected the PodGroup to be kept, got %s", action.GetVerb())
+		}
+	}
+	if conditions := tfJob.Status.Conditions; len(conditions) != 1 || conditions[0].Type != tfv1.JobPodGroupAdmitted ||
+		conditions[0].Status != v1.ConditionFalse {
+		t.Errorf("Expected the PodGroupAdmitted condition to be false, got %v", conditions)
+	}
+
+	// The tfjob is admitted once the pods of the PodGroup are scheduled.
+	if err := unstructured.SetNestedField(podGroup.Object, "Scheduled", "status", "phase"); err != nil {
+		t.Fatalf("Failed to set the phase of the PodGroup: %v", err)
+	}
+	if _, err := podGroups.Update(podGroup, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update the PodGroup: %v", err)
+	}
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	if status := tfJob.Status.PodGroupStatus; status == nil || !status.Admitted {
+		t.Errorf("Expected the PodGroup to be admitted, got %+v", status)
+	}
+	if !hasCondition(tfJob.Status.JobStatus, tfv1.JobPodGroupAdmitted) {
+		t.Errorf("Expected the PodGroupAdmitted condition, got %v", tfJob.Status.Conditions)
+	}
+
+	podTemplate := tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypePS].Template.DeepCopy()
+	ctr.setPodGangScheduling(tfJob, podTemplate, "ps")
+	if podTemplate.Spec.SchedulerName != defaultCoschedulingSchedulerName {
+		t.Errorf("Expected the pod to be scheduled by %s, got %q", defaultCoschedulingSchedulerName, podTemplate.Spec.SchedulerName)
+	}
+	if podTemplate.Labels[coschedulingPodGroupLabel] != tfJob.Name {
+		t.Errorf("Expected the pod to be labeled with its PodGroup, got %v", podTemplate.Labels)
+	}
+
+	// The PodGroup is deleted once the tfjob finishes.
+	tfJob.Status.Conditions = []commonv1.JobCondition{{Type: commonv1.JobFailed, Status: v1.ConditionTrue}}
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	if _, err := podGroups.Get(tfJob.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the PodGroup to be deleted, got %v", err)
+	}
+}
+
+func TestCoschedulingMinMembers(t *testing.T) {
+	ctr, _, dynamicClient := newGangTFController(t, options.GangSchedulingBackendCoscheduling)
+	tfJob := testutil.NewTFJob(2, 1)
+	tfJob.Spec.MinMembers = map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 1}
+
+	// The coscheduling plugin cannot require the PS, so the tfjob fails.
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	if !isFailed(tfJob.Status.JobStatus) {
+		t.Errorf("Expected the tfjob to fail, got %v", tfJob.Status.Conditions)
+	}
+	podGroups := dynamicClient.Resource(coschedulingPodGroupGVR).Namespace(metav1.NamespaceDefault)
+	if _, err := podGroups.Get(tfJob.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected no PodGroup, got %v", err)
+	}
+
+	// The minimum Workers of an elastic tfjob are reported, but it can start
+	// with the total minimum members.
+	recorder := record.NewFakeRecorder(10)
+	ctr.Recorder = recorder
+	tfJob = testutil.NewTFJob(2, 1)
+	tfJob.Spec.ElasticPolicy = &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(1)}
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	if isFailed(tfJob.Status.JobStatus) {
+		t.Errorf("Expected the elastic tfjob not to fail, got %v", tfJob.Status.Conditions)
+	}
+	podGroup, err := podGroups.Get(tfJob.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the PodGroup: %v", err)
+	}
+	if minMember, _, _ := unstructured.NestedInt64(podGroup.Object, "spec", "minMember"); minMember != 2 {
+		t.Errorf("Expected 2 members of the PodGroup, got %d", minMember)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, tfJobMinMembersUnsupportedReason) {
+			t.Errorf("Expected the %s event, got %q", tfJobMinMembersUnsupportedReason, event)
+		}
+	default:
+		t.Errorf("Expected the %s event", tfJobMinMembersUnsupportedReason)
+	}
+}
+
+func TestGenPodGroupSpecMinMembers(t *testing.T) {
+	ctr, _, _ := newGangTFController(t, options.GangSchedulingBackendVolcano)
+
+	testCases := map[string]struct {
+		mutate         func(*tfv1.TFJob)
+		minMember      int32
+		minTaskMembers map[string]int32
+		minCPU         string
+	}{
+		"all replicas": {
+			mutate:    func(*tfv1.TFJob) {},
+			minMember: 7,
+			minCPU:    "7",
+		},
+		"min available": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.RunPolicy.SchedulingPolicy = &commonv1.SchedulingPolicy{MinAvailable: tfv1.Int32(3)}
+			},
+			minMember: 3,
+			minCPU:    "3",
+		},
+		"partial workers": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.MinMembers = map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 2}
+			},
+			minMember:      5,
+			minTaskMembers: map[string]int32{"chief": 1, "ps": 2, "worker": 2},
+			minCPU:         "5",
+		},
+		"elastic workers": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.ElasticPolicy = &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(3)}
+			},
+			minMember:      6,
+			minTaskMembers: map[string]int32{"chief": 1, "ps": 2, "worker": 3},
+			minCPU:         "6",
+		},
+		"elastic workers scaled below the minimum": {
+			mutate: func(j *tfv1.TFJob) {
+				j.Spec.ElasticPolicy = &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(6)}
+			},
+			minMember:      7,
+			minTaskMembers: map[string]int32{"chief": 1, "ps": 2, "worker": 4},
+			minCPU:         "7",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := testutil.NewTFJobWithChief(4, 2)
+		for _, spec := range tfJob.Spec.TFReplicaSpecs {
+			spec.Template.Spec.Containers[0].Resources.Requests = v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}
+		}
+		c.mutate(tfJob)
+		spec := ctr.genPodGroupSpec(tfJob)
+		if spec.MinMember != c.minMember {
+			t.Errorf("%s: Expected %d members, got %d", name, c.minMember, spec.MinMember)
+		}
+		if len(spec.MinTaskMembers) != 0 || len(c.minTaskMembers) != 0 {
+			if !reflect.DeepEqual(spec.MinTaskMembers, c.minTaskMembers) {
+				t.Errorf("%s: Expected task members %v, got %v", name, c.minTaskMembers, spec.MinTaskMembers)
+			}
+		}
+		if cpu := (*spec.MinResources)[v1.ResourceCPU]; cpu.Cmp(resource.MustParse(c.minCPU)) != 0 {
+			t.Errorf("%s: Expected %s CPU, got %s", name, c.minCPU, cpu.String())
+		}
+	}
+}
+
+func TestVolcanoMinTaskMember(t *testing.T) {
+	ctr, volcanoClientSet, _ := newGangTFController(t, options.GangSchedulingBackendVolcano)
+	tfJob := testutil.NewTFJob(4, 2)
+	tfJob.Spec.MinMembers = map[commonv1.ReplicaType]int32{tfv1.TFReplicaTypeWorker: 1}
+
+	patches := func() []k8stesting.PatchAction {
+		var patches []k8stesting.PatchAction
+		for _, action := range volcanoClientSet.Actions() {
+			if patch, ok := action.(k8stesting.PatchAction); ok {
+				patches = append(patches, patch)
+			}
+		}
+		return patches
+	}
+
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	podGroup, err := volcanoClientSet.SchedulingV1beta1().PodGroups(metav1.NamespaceDefault).Get(tfJob.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the PodGroup: %v", err)
+	}
+	if podGroup.Spec.MinMember != 3 {
+		t.Errorf("Expected 3 members of the PodGroup, got %d", podGroup.Spec.MinMember)
+	}
+	if len(patches()) != 1 {
+		t.Fatalf("Expected the minimum task members to be patched once, got %d patches", len(patches()))
+	}
+	var patch struct {
+		Spec struct {
+			MinTaskMember map[string]int32 `json:"minTaskMember"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(patches()[0].GetPatch(), &patch); err != nil {
+		t.Fatalf("Failed to decode the patch: %v", err)
+	}
+	if expected := map[string]int32{"ps": 2, "worker": 1}; !reflect.DeepEqual(patch.Spec.MinTaskMember, expected) {
+		t.Errorf("Expected the minimum task members %v, got %v", expected, patch.Spec.MinTaskMember)
+	}
+	if podGroup.Annotations[volcanoMinTaskMemberAnnotation] == "" {
+		t.Errorf("Expected the minimum task members to be recorded, got %v", podGroup.Annotations)
+	}
+
+	// The minimum task members are not patched again while they do not change.
+	if err := ctr.reconcilePodGroup(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the PodGroup: %v", err)
+	}
+	if len(patches()) != 1 {
+		t.Errorf("Expected the minimum task members to be patched once, got %d patches", len(patches()))
+	}
+}
@@ -95,7 +95,16 @@ func tfJobFromUnstructured(obj interface{}) (*tfv1.TFJob, error) {
 	// This is a simple validation for TFJob to close
 	// https://github.com/kubeflow/tf-operator/issues/641
 	// TODO(gaocegege): Add more validation here.
-	err = validation.ValidateV1TFJobSpec(&tfjob.Spec)
+	// The Worker replicas set through the scale subresource bypass the admission
+	// webhook, thus they are validated once clamped to the elastic policy, and
+	// the clamped replicas are persisted by the sync of the tfjob.
+	clamped := tfjob.DeepCopy()
+	clampWorkerReplicas(clamped)
+	err = validation.ValidateV1TFJobSpec(&clamped.Spec)
+	if err == nil {
+		// The tfjobs created before the admission webhook are checked as well.
+		err = validation.ValidateV1TFJobAddresses(clamped)
+	}
 	if err != nil {
 		logger.Errorf(failedMarshalMsg, err)
 		return nil, errFailedMarshal
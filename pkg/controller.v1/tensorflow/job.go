@@ -2,6 +2,7 @@ package tensorflow
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -118,7 +119,7 @@ func (tc *TFController) addTFJob(obj interface{}) {
 	logger.Info(msg)
 
 	// Add a created condition.
-	err = commonutil.UpdateJobConditions(&tfJob.Status, commonv1.JobCreated, tfJobCreatedReason, msg)
+	err = commonutil.UpdateJobConditions(&tfJob.Status.JobStatus, commonv1.JobCreated, tfJobCreatedReason, msg)
 	if err != nil {
 		logger.Errorf("Append tfJob condition error: %v", err)
 		return
@@ -172,3 +173,122 @@ func (tc *TFController) updateTFJob(old, cur interface{}) {
 		}
 	}
 }
+
+// isSuspended returns true if the tfjob is suspended and has not finished yet.
+// It is suspended by spec.suspend, or by its Suspended condition when the
+// pending timeout policy suspended it and its spec is not updated since.
+func isSuspended(tfJob *tfv1.TFJob) bool {
+	if isSucceeded(tfJob.Status.JobStatus) || isFailed(tfJob.Status.JobStatus) {
+		return false
+	}
+	if tfJob.Spec.Suspend != nil && *tfJob.Spec.Suspend {
+		return true
+	}
+	for _, condition := range tfJob.Status.Conditions {
+		if condition.Type == tfv1.JobSuspended && condition.Status == v1.ConditionTrue {
+			return condition.Reason == tfJobPendingTimeoutReason &&
+				tfJob.Generation == tfJob.Status.SuspendedGeneration
+		}
+	}
+	return false
+}
+
+// suspendTFJob deletes all pods and services of the tfjob, and marks it as suspended.
+func (tc *TFController) suspendTFJob(tfJob *tfv1.TFJob) error {
+	logger := commonutil.LoggerForJob(tfJob)
+
+	pods, err := tc.GetPodsForJob(tfJob)
+	if err != nil {
+		logger.Warnf("GetPodsForJob error %v", err)
+		return err
+	}
+	services, err := tc.GetServicesForJob(tfJob)
+	if err != nil {
+		logger.Warnf("GetServicesForJob error %v", err)
+		return err
+	}
+	if err := tc.deleteReplicaPods(tfJob, pods); err != nil {
+		return err
+	}
+	for _, service := range services {
+		if err := tc.ServiceControl.DeleteService(service.Namespace, service.Name, tfJob); err != nil {
+			return err
+		}
+	}
+	if tc.gangScheduler != nil {
+		if err := tc.gangScheduler.DeletePodGroup(tfJob); err != nil {
+			tc.Recorder.Eventf(tfJob, v1.EventTypeWarning, "FailedDeletePodGroup", "Error deleting: %v", err)
+			return err
+		}
+	}
+
+	jobStatus := tfJob.Status.JobStatus.DeepCopy()
+	if !hasCondition(*jobStatus, tfv1.JobSuspended) {
+		msg := fmt.Sprintf("TFJob %s/%s is suspended.", tfJob.Namespace, tfJob.Name)
+		tc.Recorder.Event(tfJob, v1.EventTypeNormal, tfJobSuspendedReason, msg)
+		if err := commonutil.UpdateJobConditions(jobStatus, tfv1.JobSuspended, tfJobSuspendedReason, msg); err != nil {
+			logger.Infof("Append tfjob condition error: %v", err)
+			return err
+		}
+	}
+	// None of the replicas are running while the tfjob is suspended.
+	for i := range jobStatus.Conditions {
+		if c := &jobStatus.Conditions[i]; (c.Type == commonv1.JobRunning || c.Type == commonv1.JobRestarting) &&
+			c.Status == v1.ConditionTrue {
+			c.Status = v1.ConditionFalse
+			c.Reason = tfJobSuspendedReason
+			c.LastUpdateTime = metav1.Now()
+			c.LastTransitionTime = metav1.Now()
+		}
+	}
+	for _, status := range jobStatus.ReplicaStatuses {
+		status.Active = 0
+	}
+	if reflect.DeepEqual(tfJob.Status.JobStatus, *jobStatus) {
+		return nil
+	}
+	tfJob.Status.JobStatus = *jobStatus
+	return tc.UpdateJobStatusInApiServer(tfJob, jobStatus)
+}
+
+// resumeTFJob marks the suspended tfjob as resumed. The replicas are created
+// from scratch by the following reconciliation, which also persists the status.
+// The start time of the tfjob is postponed by the time it has been suspended,
+// so that the clock of ActiveDeadlineSeconds is paused meanwhile.
+func (tc *TFController) resumeTFJob(tfJob *tfv1.TFJob) error {
+	if !hasCondition(tfJob.Status.JobStatus, tfv1.JobSuspended) {
+		return nil
+	}
+	msg := fmt.Sprintf("TFJob %s/%s is resumed.", tfJob.Namespace, tfJob.Name)
+	tc.Recorder.Event(tfJob, v1.EventTypeNormal, tfJobResumedReason, msg)
+	now := metav1.Now()
+	for i := range tfJob.Status.Conditions {
+		c := &tfJob.Status.Conditions[i]
+		if c.Type != tfv1.JobSuspended || c.Status != v1.ConditionTrue {
+			continue
+		}
+		if tfJob.Status.StartTime != nil {
+			start := metav1.NewTime(tfJob.Status.StartTime.Add(now.Sub(c.LastTransitionTime.Time)))
+			tfJob.Status.StartTime = &start
+		}
+		c.Status = v1.ConditionFalse
+		c.Reason = tfJobResumedReason
+		c.Message = msg
+		c.LastUpdateTime = now
+		c.LastTransitionTime = now
+	}
+	tfJob.Status.SuspendedGeneration = 0
+
+	// The tfjob is synced again to check if it is past ActiveDeadlineSeconds.
+	ads := tfJob.Spec.RunPolicy.ActiveDeadlineSeconds
+	if tfJob.Status.StartTime == nil || ads == nil {
+		return nil
+	}
+	key, err := KeyFunc(tfJob)
+	if err != nil {
+		return err
+	}
+	passed := now.Sub(tfJob.Status.StartTime.Time)
+	tc.WorkQueue.AddAfter(key, time.Duration(*ads)*time.Second-passed)
+	return nil
+}
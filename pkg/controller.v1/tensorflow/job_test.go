@@ -28,10 +28,12 @@ import (
 
 	common "github.com/kubeflow/common/pkg/apis/common/v1"
 	"github.com/kubeflow/common/pkg/controller.v1/control"
+	"github.com/kubeflow/common/pkg/controller.v1/expectation"
 	commonutil "github.com/kubeflow/common/pkg/util"
 	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	tfjobfake "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned/fake"
 	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
 )
 
@@ -67,6 +69,8 @@ func TestAddTFJob(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 	tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 
 	stopCh := make(chan struct{})
@@ -135,6 +139,8 @@ func TestCopyLabelsAndAnnotation(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 	tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 
 	stopCh := make(chan struct{})
@@ -163,7 +169,7 @@ func TestCopyLabelsAndAnnotation(t *testing.T) {
 		t.Errorf("Failed to add tfjob to tfJobIndexer: %v", err)
 	}
 
-	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status, &tfJob.Spec.RunPolicy)
+	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status.JobStatus, &tfJob.Spec.RunPolicy)
 
 	if len(fakePodControl.Templates) != 1 {
 		t.Errorf("Expected to create 1 pod while got %d", len(fakePodControl.Templates))
@@ -323,10 +329,12 @@ func TestDeletePodsAndServices(t *testing.T) {
 		ctr.tfJobInformerSynced = testutil.AlwaysReady
 		ctr.PodInformerSynced = testutil.AlwaysReady
 		ctr.ServiceInformerSynced = testutil.AlwaysReady
+		ctr.configMapInformerSynced = testutil.AlwaysReady
+		ctr.pdbInformerSynced = testutil.AlwaysReady
 		tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 
 		// Set succeeded to run the logic about deleting.
-		err := commonutil.UpdateJobConditions(&tc.tfJob.Status, common.JobSucceeded, tfJobSucceededReason, "")
+		err := commonutil.UpdateJobConditions(&tc.tfJob.Status.JobStatus, common.JobSucceeded, tfJobSucceededReason, "")
 		if err != nil {
 			t.Errorf("Append tfjob condition error: %v", err)
 		}
@@ -348,7 +356,7 @@ func TestDeletePodsAndServices(t *testing.T) {
 		testutil.SetServices(serviceIndexer, tc.tfJob, testutil.LabelWorker, tc.activeWorkerServices, t)
 		testutil.SetServices(serviceIndexer, tc.tfJob, testutil.LabelPS, tc.activePSServices, t)
 
-		_ = ctr.ReconcileJobs(tc.tfJob, tc.tfJob.Spec.TFReplicaSpecs, tc.tfJob.Status, &tc.tfJob.Spec.RunPolicy)
+		_ = ctr.ReconcileJobs(tc.tfJob, tc.tfJob.Spec.TFReplicaSpecs, tc.tfJob.Status.JobStatus, &tc.tfJob.Spec.RunPolicy)
 		// forget, err := ctr.syncTFJob(testutil.GetKey(tc.tfJob, t))
 		// if err != nil {
 		// 	t.Errorf("%s: unexpected error when syncing jobs %v", tc.description, err)
@@ -499,7 +507,7 @@ func TestDeletePodsAndServices(t *testing.T) {
 
 // 		// Set succeeded to run the logic about deleting.
 // 		testutil.SetTFJobCompletionTime(tc.tfJob)
-// 		err := commonutil.UpdateJobConditions(&tc.tfJob.Status, common.JobSucceeded, tfJobSucceededReason, "")
+// 		err := commonutil.UpdateJobConditions(&tc.tfJob.Status.JobStatus, common.JobSucceeded, tfJobSucceededReason, "")
 // 		if err != nil {
 // 			t.Errorf("Append tfjob condition error: %v", err)
 // 		}
@@ -528,7 +536,7 @@ func TestDeletePodsAndServices(t *testing.T) {
 // 		}
 
 // 		//forget, err := ctr.syncTFJob(testutil.GetKey(tc.tfJob, t))
-// 		_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status, &tfJob.Spec.RunPolicy)
+// 		_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status.JobStatus, &tfJob.Spec.RunPolicy)
 // 		ctr.DeleteJob = func(job interface{}) error {
 // 			deleteFinished = true
 // 			return nil
@@ -645,6 +653,8 @@ func TestActiveDeadlineSeconds(t *testing.T) {
 		ctr.tfJobInformerSynced = testutil.AlwaysReady
 		ctr.PodInformerSynced = testutil.AlwaysReady
 		ctr.ServiceInformerSynced = testutil.AlwaysReady
+		ctr.configMapInformerSynced = testutil.AlwaysReady
+		ctr.pdbInformerSynced = testutil.AlwaysReady
 		tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 
 		unstructured, err := testutil.ConvertTFJobToUnstructured(tc.tfJob)
@@ -674,7 +684,7 @@ func TestActiveDeadlineSeconds(t *testing.T) {
 			time.Sleep(dur)
 		}
 
-		_ = ctr.ReconcileJobs(foo, foo.Spec.TFReplicaSpecs, foo.Status, &foo.Spec.RunPolicy)
+		_ = ctr.ReconcileJobs(foo, foo.Spec.TFReplicaSpecs, foo.Status.JobStatus, &foo.Spec.RunPolicy)
 		// if err != nil {
 		// 	t.Errorf("%s: unexpected error when syncing jobs %v", tc.description, err)
 		// }
@@ -772,6 +782,8 @@ func TestBackoffForOnFailure(t *testing.T) {
 		ctr.tfJobInformerSynced = testutil.AlwaysReady
 		ctr.PodInformerSynced = testutil.AlwaysReady
 		ctr.ServiceInformerSynced = testutil.AlwaysReady
+		ctr.configMapInformerSynced = testutil.AlwaysReady
+		ctr.pdbInformerSynced = testutil.AlwaysReady
 		tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 
 		unstructured, err := testutil.ConvertTFJobToUnstructured(tc.tfJob)
@@ -791,7 +803,7 @@ func TestBackoffForOnFailure(t *testing.T) {
 		testutil.SetServices(serviceIndexer, tc.tfJob, testutil.LabelWorker, tc.activeWorkerServices, t)
 		testutil.SetServices(serviceIndexer, tc.tfJob, testutil.LabelPS, tc.activePSServices, t)
 
-		_ = ctr.ReconcileJobs(tc.tfJob, tc.tfJob.Spec.TFReplicaSpecs, tc.tfJob.Status, &tc.tfJob.Spec.RunPolicy)
+		_ = ctr.ReconcileJobs(tc.tfJob, tc.tfJob.Spec.TFReplicaSpecs, tc.tfJob.Status.JobStatus, &tc.tfJob.Spec.RunPolicy)
 		// forget, err := ctr.syncTFJob(testutil.GetKey(tc.tfJob, t))
 		// if err != nil {
 		// 	t.Errorf("%s: unexpected error when syncing jobs %v", tc.description, err)
@@ -808,3 +820,127 @@ func TestBackoffForOnFailure(t *testing.T) {
 		}
 	}
 }
+
+func TestSuspendTFJob(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJob := testutil.NewTFJob(4, 2)
+	tfJobClientSet := tfjobfake.NewSimpleClientset(tfJob.DeepCopy())
+	ctr, kubeInformerFactory, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+	fakeServiceControl := &control.FakeServiceControl{}
+	ctr.ServiceControl = fakeServiceControl
+	ctr.Recorder = &record.FakeRecorder{}
+	ctr.tfJobInformerSynced = testutil.AlwaysReady
+	ctr.PodInformerSynced = testutil.AlwaysReady
+	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
+
+	suspend := true
+	tfJob.Spec.Suspend = &suspend
+	ads := int64(3600)
+	tfJob.Spec.RunPolicy.ActiveDeadlineSeconds = &ads
+	start := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	tfJob.Status.StartTime = &start
+	if err := commonutil.UpdateJobConditions(&tfJob.Status.JobStatus, common.JobRunning, tfJobRunningReason, ""); err != nil {
+		t.Errorf("Append tfjob condition error: %v", err)
+	}
+
+	podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	testutil.SetPodsStatuses(podIndexer, tfJob, testutil.LabelWorker, 1, 3, 0, 0, nil, t)
+	testutil.SetPodsStatuses(podIndexer, tfJob, testutil.LabelPS, 0, 2, 0, 0, nil, t)
+	// The terminating pod is not deleted again.
+	terminating := testutil.NewPod(tfJob, testutil.LabelWorker, 4)
+	deletionTimestamp := metav1.Now()
+	terminating.DeletionTimestamp = &deletionTimestamp
+	if err := podIndexer.Add(terminating); err != nil {
+		t.Fatalf("Failed to add the terminating pod: %v", err)
+	}
+	serviceIndexer := kubeInformerFactory.Core().V1().Services().Informer().GetIndexer()
+	testutil.SetServices(serviceIndexer, tfJob, testutil.LabelWorker, 4, t)
+	testutil.SetServices(serviceIndexer, tfJob, testutil.LabelPS, 2, t)
+
+	if !isSuspended(tfJob) {
+		t.Fatalf("Expected the TFJob to be suspended")
+	}
+	if err := ctr.suspendTFJob(tfJob); err != nil {
+		t.Fatalf("Failed to suspend the TFJob: %v", err)
+	}
+
+	if len(fakePodControl.DeletePodName) != 6 {
+		t.Errorf("Expected 6 pod deletions, saw %d", len(fakePodControl.DeletePodName))
+	}
+	tfJobKey := testutil.GetKey(tfJob, t)
+	for rtype, deletions := range map[string]int64{testutil.LabelWorker: 4, testutil.LabelPS: 2} {
+		exp, found, err := ctr.Expectations.GetExpectations(expectation.GenExpectationPodsKey(tfJobKey, rtype))
+		if err != nil || !found {
+			t.Fatalf("Expected the deletions of the %s pods to be expected, got %v", rtype, err)
+		}
+		if _, del := exp.GetExpectations(); del != deletions {
+			t.Errorf("Expected %d deletions of the %s pods, got %d", deletions, rtype, del)
+		}
+	}
+	if len(fakeServiceControl.DeleteServiceName) != 6 {
+		t.Errorf("Expected 6 service deletions, saw %d", len(fakeServiceControl.DeleteServiceName))
+	}
+	if !hasCondition(tfJob.Status.JobStatus, tfv1.JobSuspended) {
+		t.Errorf("Expected condition %s, got %v", tfv1.JobSuspended, tfJob.Status.Conditions)
+	}
+	if hasCondition(tfJob.Status.JobStatus, common.JobRunning) {
+		t.Errorf("Expected condition %s to be false, got %v", common.JobRunning, tfJob.Status.Conditions)
+	}
+	if !tfJob.Status.StartTime.Equal(&start) {
+		t.Errorf("Expected the start time to be kept, got %v", tfJob.Status.StartTime)
+	}
+
+	// The tfjob has been suspended for an hour.
+	start = metav1.NewTime(start.Add(-time.Hour))
+	tfJob.Status.StartTime = &start
+	for i := range tfJob.Status.Conditions {
+		if c := &tfJob.Status.Conditions[i]; c.Type == tfv1.JobSuspended {
+			c.LastTransitionTime = metav1.NewTime(c.LastTransitionTime.Add(-time.Hour))
+		}
+	}
+	suspend = false
+	if isSuspended(tfJob) {
+		t.Fatalf("Expected the TFJob not to be suspended")
+	}
+	if err := ctr.resumeTFJob(tfJob); err != nil {
+		t.Fatalf("Failed to resume the TFJob: %v", err)
+	}
+	if hasCondition(tfJob.Status.JobStatus, tfv1.JobSuspended) {
+		t.Errorf("Expected condition %s to be false, got %v", tfv1.JobSuspended, tfJob.Status.Conditions)
+	}
+	// The time the tfjob has been suspended does not count towards ActiveDeadlineSeconds.
+	if passed := time.Since(tfJob.Status.StartTime.Time); passed < 9*time.Minute || passed > 11*time.Minute {
+		t.Errorf("Expected the tfjob to be active for 10 minutes, got %v", passed)
+	}
+	if ctr.PastActiveDeadline(&tfJob.Spec.RunPolicy, tfJob.Status.JobStatus) {
+		t.Errorf("Expected the tfjob not to be past its active deadline")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+// oomKilledReason is the reason of a container which is terminated because it
+// ran out of memory, which is also the reason of the event about it.
+const oomKilledReason = "OOMKilled"
+
+// isOOMKilled returns if the container with the given name of the pod is
+// terminated because it ran out of memory. The container which was OOMKilled
+// before it was restarted by the kubelet only counts if the pod has failed,
+// otherwise it may have recovered.
+func isOOMKilled(pod *v1.Pod, containerName string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != containerName {
+			continue
+		}
+		terminated := status.State.Terminated
+		if terminated == nil && pod.Status.Phase == v1.PodFailed {
+			terminated = status.LastTerminationState.Terminated
+		}
+		return terminated != nil && terminated.Reason == oomKilledReason
+	}
+	return false
+}
+
+// escalateQuantity returns the quantity raised by the factor of the policy up
+// to its ceiling, or nil if it can not be raised any further.
+func escalateQuantity(q resource.Quantity, policy *tfv1.MemoryEscalationPolicy) *resource.Quantity {
+	if q.IsZero() || q.Cmp(policy.MaxMemory) >= 0 {
+		return nil
+	}
+	escalated := resource.NewQuantity(q.Value()*int64(policy.FactorPercent)/100, q.Format)
+	if escalated.Cmp(policy.MaxMemory) > 0 {
+		ceiling := policy.MaxMemory.DeepCopy()
+		return &ceiling
+	}
+	return escalated
+}
+
+// escalateMemory returns the memory request and limit of the container with the
+// given name of the pod raised by the memory escalation policy of the tfjob. It
+// returns false if neither of them can be raised.
+func escalateMemory(tfjob *tfv1.TFJob, pod *v1.Pod, containerName string) (*resource.Quantity, *resource.Quantity, bool) {
+	policy := tfjob.Spec.MemoryEscalationPolicy
+	if policy == nil {
+		return nil, nil, false
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != containerName {
+			continue
+		}
+		request := escalateQuantity(container.Resources.Requests[v1.ResourceMemory], policy)
+		limit := escalateQuantity(container.Resources.Limits[v1.ResourceMemory], policy)
+		return request, limit, request != nil || limit != nil
+	}
+	return nil, nil, false
+}
+
+// handleOOMKilled reports with the OOMKilled condition that the replica of the
+// pod ran out of memory, and deletes the pod to recreate it with more memory if
+// the memory escalation policy of the tfjob allows. It returns true if the pod
+// is deleted.
+func (tc *TFController) handleOOMKilled(tfjob *tfv1.TFJob, jobStatus *commonv1.JobStatus,
+	rtype commonv1.ReplicaType, index int, pod *v1.Pod, containerName string) (bool, error) {
+
+	if pod.DeletionTimestamp != nil || !isOOMKilled(pod, containerName) {
+		return false, nil
+	}
+	logger := commonutil.LoggerForJob(tfjob)
+	msg := fmt.Sprintf("%s replica %d of TFJob %s ran out of memory in pod %s/%s.",
+		rtype, index, tfjob.Name, pod.Namespace, pod.Name)
+	setProgressCondition(jobStatus, tfv1.JobOOMKilled, v1.ConditionTrue, tfJobOOMKilledReason, msg)
+
+	request, limit, ok := escalateMemory(tfjob, pod, containerName)
+	if !ok {
+		return false, nil
+	}
+	logger.Infof("Need to recreate the pod: %v.%v with memory request %v and limit %v",
+		pod.Namespace, pod.Name, request, limit)
+	if err := tc.PodControl.DeletePod(pod.Namespace, pod.Name, tfjob); err != nil {
+		return false, err
+	}
+	recordReplicaRestart(&tfjob.Status, rtype, index)
+	status := getReplicaIndexStatus(&tfjob.Status, rtype, index)
+	if request != nil {
+		status.MemoryRequest = request
+	}
+	if limit != nil {
+		status.MemoryLimit = limit
+	}
+
+	msg = fmt.Sprintf("TFJob %s is restarting %s replica %d with more memory because it ran out of memory.",
+		tfjob.Name, rtype, index)
+	tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobRestartingReason, msg)
+	if err := commonutil.UpdateJobConditions(jobStatus, commonv1.JobRestarting, tfJobRestartingReason, msg); err != nil {
+		logger.Infof("Append tfjob condition error: %v", err)
+		return true, err
+	}
+	tfJobsRestartCount.WithLabelValues(tfjob.Namespace).Inc()
+	return true, nil
+}
+
+// setReplicaMemory sets the memory request and limit of the TensorFlow container
+// of the replica raised by the memory escalation policy of the tfjob.
+func setReplicaMemory(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec, rt, index string) {
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return
+	}
+	var status *tfv1.ReplicaIndexStatus
+	for rtype := range tfjob.Status.ReplicaIndexStatuses {
+		if strings.ToLower(string(rtype)) == rt {
+			status = getReplicaIndexStatus(&tfjob.Status, rtype, i)
+		}
+	}
+	if status == nil || (status.MemoryRequest == nil && status.MemoryLimit == nil) {
+		return
+	}
+	containerName := tfv1.GetContainerName(&tfjob.Spec, podTemplate.Annotations)
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Name != containerName {
+			continue
+		}
+		if status.MemoryRequest != nil {
+			if container.Resources.Requests == nil {
+				container.Resources.Requests = v1.ResourceList{}
+			}
+			container.Resources.Requests[v1.ResourceMemory] = status.MemoryRequest.DeepCopy()
+		}
+		if status.MemoryLimit != nil {
+			if container.Resources.Limits == nil {
+				container.Resources.Limits = v1.ResourceList{}
+			}
+			container.Resources.Limits[v1.ResourceMemory] = status.MemoryLimit.DeepCopy()
+		}
+	}
+}
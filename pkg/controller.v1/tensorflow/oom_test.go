@@ -0,0 +1,189 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/common/pkg/controller.v1/control"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestEscalateQuantity(t *testing.T) {
+	policy := &tfv1.MemoryEscalationPolicy{FactorPercent: 150, MaxMemory: resource.MustParse("4Gi")}
+	testCases := map[string]struct {
+		quantity string
+		expected string
+	}{
+		"raised":      {quantity: "2Gi", expected: "3Gi"},
+		"capped":      {quantity: "3Gi", expected: "4Gi"},
+		"at ceiling":  {quantity: "4Gi", expected: ""},
+		"unspecified": {quantity: "0", expected: ""},
+	}
+	for name, tc := range testCases {
+		actual := escalateQuantity(resource.MustParse(tc.quantity), policy)
+		if tc.expected == "" {
+			if actual != nil {
+				t.Errorf("%s: expected no escalation, got %v", name, actual)
+			}
+			continue
+		}
+		if actual == nil || actual.Cmp(resource.MustParse(tc.expected)) != 0 {
+			t.Errorf("%s: expected %s, got %v", name, tc.expected, actual)
+		}
+	}
+}
+
+func TestIsOOMKilled(t *testing.T) {
+	oomKilled := &v1.ContainerStateTerminated{ExitCode: 137, Reason: oomKilledReason}
+	testCases := map[string]struct {
+		phase    v1.PodPhase
+		status   v1.ContainerStatus
+		expected bool
+	}{
+		"terminated": {
+			v1.PodFailed,
+			v1.ContainerStatus{State: v1.ContainerState{Terminated: oomKilled}},
+			true,
+		},
+		"terminated before the pod fails": {
+			v1.PodRunning,
+			v1.ContainerStatus{State: v1.ContainerState{Terminated: oomKilled}},
+			true,
+		},
+		"restarted in place": {
+			v1.PodRunning,
+			v1.ContainerStatus{LastTerminationState: v1.ContainerState{Terminated: oomKilled}},
+			false,
+		},
+		"succeeded after a restart": {
+			v1.PodSucceeded,
+			v1.ContainerStatus{
+				State:                v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}},
+				LastTerminationState: v1.ContainerState{Terminated: oomKilled},
+			},
+			false,
+		},
+		"failed after a restart": {
+			v1.PodFailed,
+			v1.ContainerStatus{LastTerminationState: v1.ContainerState{Terminated: oomKilled}},
+			true,
+		},
+	}
+	for name, tc := range testCases {
+		pod := &v1.Pod{}
+		pod.Status.Phase = tc.phase
+		tc.status.Name = tfv1.DefaultContainerName
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{tc.status}
+		if got := isOOMKilled(pod, tfv1.DefaultContainerName); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, got)
+		}
+	}
+}
+
+func TestOOMKilledMemoryEscalation(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, _, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+
+	tfJob := testutil.NewTFJob(1, 0)
+	tfJob.Spec.MemoryEscalationPolicy = &tfv1.MemoryEscalationPolicy{FactorPercent: 200, MaxMemory: resource.MustParse("3Gi")}
+	rtype := tfv1.TFReplicaTypeWorker
+	spec := tfJob.Spec.TFReplicaSpecs[rtype]
+	spec.RestartPolicy = commonv1.RestartPolicyNever
+	spec.Template.Spec.Containers[0].Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}
+
+	pod := testutil.NewPod(tfJob, testutil.LabelWorker, 0)
+	pod.Spec = *spec.Template.Spec.DeepCopy()
+	pod.Status.Phase = v1.PodFailed
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name: tfv1.DefaultContainerName,
+		State: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: oomKilledReason},
+		},
+	}}
+	err := ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, []*v1.Pod{pod}, rtype, spec, tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.DeletePodName) != 1 || fakePodControl.DeletePodName[0] != pod.Name {
+		t.Errorf("Expected pod %s to be deleted, got %v", pod.Name, fakePodControl.DeletePodName)
+	}
+	status := getReplicaIndexStatus(&tfJob.Status, rtype, 0)
+	if status == nil || status.TerminationReason != oomKilledReason {
+		t.Errorf("Expected the termination reason %s, got %v", oomKilledReason, status)
+	}
+	conditions := tfJob.Status.Conditions
+	if !hasCondition(tfJob.Status.JobStatus, tfv1.JobOOMKilled) || conditions[len(conditions)-1].Type != commonv1.JobRestarting {
+		t.Errorf("Expected the OOMKilled condition before the Restarting condition, got %v", conditions)
+	}
+	if status == nil || status.MemoryLimit == nil || status.MemoryLimit.Cmp(resource.MustParse("2Gi")) != 0 {
+		t.Fatalf("Expected the memory limit to be raised to 2Gi, got %v", status)
+	}
+
+	// The replica is recreated with the raised memory limit.
+	ctr.restartBackoffBase = 0
+	err = ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, nil, rtype, spec, tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.Templates) != 1 {
+		t.Fatalf("Expected 1 pod to be created, got %d", len(fakePodControl.Templates))
+	}
+	limit := fakePodControl.Templates[0].Spec.Containers[0].Resources.Limits[v1.ResourceMemory]
+	if limit.Cmp(resource.MustParse("2Gi")) != 0 {
+		t.Errorf("Expected the memory limit of the new pod to be 2Gi, got %v", limit.String())
+	}
+	if spec.Template.Spec.Containers[0].Resources.Limits.Memory().Cmp(resource.MustParse("1Gi")) != 0 {
+		t.Errorf("Expected the replica spec to be kept, got %v", spec.Template.Spec.Containers[0].Resources.Limits)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+// genPDBName returns the name of the PodDisruptionBudget of the tfjob.
+func genPDBName(tfjob *tfv1.TFJob) string {
+	return tfjob.Name
+}
+
+// pdbReplicaTypes are the replica types of the training members, which are
+// covered by the PodDisruptionBudget. The Evaluator only reads the checkpoints.
+var pdbReplicaTypes = []commonv1.ReplicaType{
+	tfv1.TFReplicaTypePS,
+	tfv1.TFReplicaTypeChief,
+	tfv1.TFReplicaTypeMaster,
+	tfv1.TFReplicaTypeWorker,
+	tfv1.TFReplicaTypeCoordinator,
+}
+
+// pdbMinAvailable returns the number of training members of the tfjob which
+// have to stay available, i.e. the members which can still become available as
+// they have neither succeeded nor failed, or the MinAvailable of its scheduling
+// policy if it is set and lower. The failed members tolerated by the failure
+// policy are not recreated, and the others are not available until they are.
+func pdbMinAvailable(tfjob *tfv1.TFJob) int32 {
+	members := int32(0)
+	for _, rtype := range pdbReplicaTypes {
+		spec, ok := tfjob.Spec.TFReplicaSpecs[rtype]
+		if !ok || spec.Replicas == nil {
+			continue
+		}
+		members += *spec.Replicas
+		if status, ok := tfjob.Status.ReplicaStatuses[rtype]; ok && status != nil {
+			members -= status.Succeeded + status.Failed
+		}
+	}
+	if members < 0 {
+		members = 0
+	}
+	if policy := tfjob.Spec.RunPolicy.SchedulingPolicy; policy != nil && policy.MinAvailable != nil && *policy.MinAvailable < members {
+		return *policy.MinAvailable
+	}
+	return members
+}
+
+// pdbSelector returns the selector of the pods of the training members of the tfjob.
+func (tc *TFController) pdbSelector(tfjob *tfv1.TFJob) *metav1.LabelSelector {
+	var rts []string
+	for _, rtype := range pdbReplicaTypes {
+		rts = append(rts, strings.ToLower(string(rtype)))
+	}
+	return &metav1.LabelSelector{
+		MatchLabels: tc.GenLabels(tfjob.Name),
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      commonv1.ReplicaTypeLabel,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   rts,
+		}},
+	}
+}
+
+// reconcilePodDisruptionBudget creates the PodDisruptionBudget covering the pods
+// of the training members of the tfjob once it is running, which prevents
+// voluntary evictions, e.g. node drains, from killing its replicas. It is
+// deleted once the tfjob finishes or is suspended. The policy/v1beta1 API is
+// the only one served by the supported Kubernetes versions.
+func (tc *TFController) reconcilePodDisruptionBudget(tfjob *tfv1.TFJob) error {
+	pdb, err := tc.pdbLister.PodDisruptionBudgets(tfjob.Namespace).Get(genPDBName(tfjob))
+	if errors.IsNotFound(err) {
+		pdb = nil
+	} else if err != nil {
+		return err
+	}
+
+	pdbs := tc.KubeClientSet.PolicyV1beta1().PodDisruptionBudgets(tfjob.Namespace)
+	if isSucceeded(tfjob.Status.JobStatus) || isFailed(tfjob.Status.JobStatus) || isSuspended(tfjob) {
+		if pdb == nil || !metav1.IsControlledBy(pdb, tfjob) {
+			return nil
+		}
+		commonutil.LoggerForJob(tfjob).Infof("Delete PodDisruptionBudget %s", pdb.Name)
+		if err := pdbs.Delete(pdb.Name, nil); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if pdb != nil && !metav1.IsControlledBy(pdb, tfjob) {
+		// The budget of another object would keep the replicas from being
+		// evicted, or let them be evicted, regardless of the tfjob, thus the
+		// tfjob fails rather than waiting for the PodDisruptionBudget to be deleted.
+		msg := fmt.Sprintf("TFJob %s has failed because PodDisruptionBudget %s/%s already exists and is not owned by it.",
+			tfjob.Name, pdb.Namespace, pdb.Name)
+		tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobPDBConflictReason, msg)
+		if err := commonutil.UpdateJobConditions(&tfjob.Status.JobStatus, commonv1.JobFailed, tfJobPDBConflictReason, msg); err != nil {
+			commonutil.LoggerForJob(tfjob).Infof("Append tfjob condition error: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	minAvailable := intstr.FromInt(int(pdbMinAvailable(tfjob)))
+	selector := tc.pdbSelector(tfjob)
+	if pdb != nil {
+		if pdb.Spec.MinAvailable != nil && *pdb.Spec.MinAvailable == minAvailable &&
+			equality.Semantic.DeepEqual(pdb.Spec.Selector, selector) {
+			return nil
+		}
+		// The replicas of the tfjob have been scaled or have succeeded.
+		commonutil.LoggerForJob(tfjob).Infof("Update PodDisruptionBudget %s to %s", pdb.Name, minAvailable.String())
+		pdb = pdb.DeepCopy()
+		pdb.Spec.MinAvailable = &minAvailable
+		pdb.Spec.Selector = selector
+		_, err = pdbs.Update(pdb)
+		return err
+	}
+	if !hasCondition(tfjob.Status.JobStatus, commonv1.JobRunning) {
+		return nil
+	}
+
+	commonutil.LoggerForJob(tfjob).Infof("Create PodDisruptionBudget %s", genPDBName(tfjob))
+	_, err = pdbs.Create(&policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            genPDBName(tfjob),
+			Namespace:       tfjob.Namespace,
+			Labels:          tc.GenLabels(tfjob.Name),
+			OwnerReferences: []metav1.OwnerReference{*tc.GenOwnerReference(tfjob)},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     selector,
+		},
+	})
+	return err
+}
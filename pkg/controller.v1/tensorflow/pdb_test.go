@@ -0,0 +1,167 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestReconcilePodDisruptionBudget(t *testing.T) {
+	kubeClientSet := kubefake.NewSimpleClientset()
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, kubeInformerFactory, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	pdbs := kubeClientSet.PolicyV1beta1().PodDisruptionBudgets(metav1.NamespaceDefault)
+	pdbIndexer := kubeInformerFactory.Policy().V1beta1().PodDisruptionBudgets().Informer().GetIndexer()
+	// reconcile reconciles the PodDisruptionBudget, and then updates the store
+	// of the shared informer like the informer would.
+	reconcile := func(tfJob *tfv1.TFJob) {
+		if err := ctr.reconcilePodDisruptionBudget(tfJob); err != nil {
+			t.Fatalf("Failed to reconcile the PodDisruptionBudget: %v", err)
+		}
+		list, err := pdbs.List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("Failed to list the PodDisruptionBudgets: %v", err)
+		}
+		var objs []interface{}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+		if err := pdbIndexer.Replace(objs, ""); err != nil {
+			t.Fatalf("Failed to update the PodDisruptionBudget store: %v", err)
+		}
+	}
+
+	tfJob := testutil.NewTFJob(2, 1)
+	setCondition := func(condType commonv1.JobConditionType) {
+		tfJob.Status.Conditions = []commonv1.JobCondition{{Type: condType, Status: v1.ConditionTrue}}
+	}
+
+	// No PodDisruptionBudget is created before the tfjob is running.
+	reconcile(tfJob)
+	if _, err := pdbs.Get(tfJob.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected no PodDisruptionBudget before the tfjob is running, got %v", err)
+	}
+
+	setCondition(commonv1.JobRunning)
+	reconcile(tfJob)
+	pdb, err := pdbs.Get(tfJob.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the PodDisruptionBudget: %v", err)
+	}
+	if pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.IntValue() != 3 {
+		t.Errorf("Expected 3 replicas to stay available, got %v", pdb.Spec.MinAvailable)
+	}
+	for key, value := range ctr.GenLabels(tfJob.Name) {
+		if pdb.Spec.Selector.MatchLabels[key] != value {
+			t.Errorf("Expected the PodDisruptionBudget to select %s=%s, got %v", key, value, pdb.Spec.Selector)
+		}
+	}
+	if len(pdb.OwnerReferences) != 1 || pdb.OwnerReferences[0].Name != tfJob.Name {
+		t.Errorf("Expected the PodDisruptionBudget to be owned by the tfjob, got %v", pdb.OwnerReferences)
+	}
+
+	// The minimum available replicas follow the scheduling policy.
+	minAvailable := int32(2)
+	tfJob.Spec.RunPolicy.SchedulingPolicy = &commonv1.SchedulingPolicy{MinAvailable: &minAvailable}
+	reconcile(tfJob)
+	if pdb, err = pdbs.Get(tfJob.Name, metav1.GetOptions{}); err != nil || pdb.Spec.MinAvailable.IntValue() != 2 {
+		t.Errorf("Expected 2 replicas to stay available, got %v (%v)", pdb.Spec.MinAvailable, err)
+	}
+
+	// The succeeded members and the evaluator are not counted.
+	tfJob.Spec.RunPolicy.SchedulingPolicy = nil
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeEval] = tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].DeepCopy()
+	tfJob.Status.ReplicaStatuses = map[commonv1.ReplicaType]*commonv1.ReplicaStatus{
+		tfv1.TFReplicaTypeWorker: {Succeeded: 1},
+	}
+	reconcile(tfJob)
+	if pdb, err = pdbs.Get(tfJob.Name, metav1.GetOptions{}); err != nil || pdb.Spec.MinAvailable.IntValue() != 2 {
+		t.Errorf("Expected 2 replicas to stay available, got %v (%v)", pdb.Spec.MinAvailable, err)
+	}
+	if requirements := pdb.Spec.Selector.MatchExpressions; len(requirements) != 1 ||
+		requirements[0].Key != commonv1.ReplicaTypeLabel || len(requirements[0].Values) != len(pdbReplicaTypes) {
+		t.Errorf("Expected the PodDisruptionBudget to select the training members, got %v", pdb.Spec.Selector)
+	}
+
+	// Nor are the failed members, which are either tolerated or not available
+	// until they are recreated.
+	tfJob.Status.ReplicaStatuses[tfv1.TFReplicaTypePS] = &commonv1.ReplicaStatus{Failed: 1}
+	reconcile(tfJob)
+	if pdb, err = pdbs.Get(tfJob.Name, metav1.GetOptions{}); err != nil || pdb.Spec.MinAvailable.IntValue() != 1 {
+		t.Errorf("Expected 1 replica to stay available, got %v (%v)", pdb.Spec.MinAvailable, err)
+	}
+
+	// The PodDisruptionBudget is deleted once the tfjob finishes.
+	setCondition(commonv1.JobSucceeded)
+	reconcile(tfJob)
+	if _, err := pdbs.Get(tfJob.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the PodDisruptionBudget to be deleted, got %v", err)
+	}
+
+	// A PodDisruptionBudget owned by another object fails the tfjob.
+	conflict := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: tfJob.Name, Namespace: tfJob.Namespace},
+	}
+	if _, err := pdbs.Create(conflict); err != nil {
+		t.Fatalf("Failed to create the PodDisruptionBudget: %v", err)
+	}
+	if err := pdbIndexer.Add(conflict); err != nil {
+		t.Fatalf("Failed to add the PodDisruptionBudget to the store: %v", err)
+	}
+	setCondition(commonv1.JobRunning)
+	reconcile(tfJob)
+	if !isFailed(tfJob.Status.JobStatus) {
+		t.Errorf("Expected the tfjob to fail, got %v", tfJob.Status.Conditions)
+	}
+	if _, err := pdbs.Get(tfJob.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the PodDisruptionBudget to be kept, got %v", err)
+	}
+	reconcile(tfJob)
+	if _, err := pdbs.Get(tfJob.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the PodDisruptionBudget to be kept once the tfjob fails, got %v", err)
+	}
+}
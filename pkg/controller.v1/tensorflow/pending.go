@@ -0,0 +1,112 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+// podPendingReason returns why the pod is pending. The reason of a waiting
+// container, e.g. "ImagePullBackOff", takes precedence over the reason of the
+// pod not being scheduled, e.g. "Unschedulable".
+func podPendingReason(pod *v1.Pod) (string, string) {
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" &&
+			waiting.Reason != "ContainerCreating" && waiting.Reason != "PodInitializing" {
+			return waiting.Reason, waiting.Message
+		}
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse && condition.Reason != "" {
+			return condition.Reason, condition.Message
+		}
+	}
+	return string(v1.PodPending), ""
+}
+
+// pendingTimeoutRemaining returns how long the pod may still stay Pending
+// according to the pending timeout policy of the tfjob.
+func pendingTimeoutRemaining(policy *tfv1.PendingTimeoutPolicy, pod *v1.Pod, now time.Time) time.Duration {
+	timeout := time.Duration(policy.TimeoutSeconds) * time.Second
+	return pod.CreationTimestamp.Add(timeout).Sub(now)
+}
+
+// checkPendingTimeout fails or suspends the tfjob if the pod has been Pending
+// for longer than its pending timeout, and requeues the tfjob to check it again
+// when the timeout expires otherwise. It returns true if the timeout expired.
+func (tc *TFController) checkPendingTimeout(tfjob *tfv1.TFJob, jobStatus *commonv1.JobStatus,
+	rtype commonv1.ReplicaType, pod *v1.Pod) (bool, error) {
+
+	policy := tfjob.Spec.PendingTimeoutPolicy
+	if policy == nil || pod.Status.Phase != v1.PodPending || pod.DeletionTimestamp != nil || isFailed(*jobStatus) {
+		return false, nil
+	}
+	if remaining := pendingTimeoutRemaining(policy, pod, time.Now()); remaining > 0 {
+		key, err := KeyFunc(tfjob)
+		if err != nil {
+			return false, err
+		}
+		tc.WorkQueue.AddAfter(key, remaining)
+		return false, nil
+	}
+
+	reason, message := podPendingReason(pod)
+	if message != "" {
+		reason = fmt.Sprintf("%s: %s", reason, message)
+	}
+	msg := fmt.Sprintf("%s replica pod %s/%s has been pending for more than %d seconds (%s).",
+		rtype, pod.Namespace, pod.Name, policy.TimeoutSeconds, reason)
+	logger := commonutil.LoggerForJob(tfjob)
+	logger.Info(msg)
+
+	if policy.Action == tfv1.PendingTimeoutActionSuspend {
+		// The tfjob is suspended by its Suspended condition, not by its spec,
+		// until its spec is updated, see isSuspended.
+		if hasCondition(*jobStatus, tfv1.JobSuspended) {
+			return true, nil
+		}
+		tfjob.Status.SuspendedGeneration = tfjob.Generation
+		msg = fmt.Sprintf("TFJob %s/%s is suspended because %s", tfjob.Namespace, tfjob.Name, msg)
+		tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobPendingTimeoutReason, msg)
+		// The pods are deleted by the following sync, which keeps the condition.
+		if err := commonutil.UpdateJobConditions(jobStatus, tfv1.JobSuspended, tfJobPendingTimeoutReason, msg); err != nil {
+			logger.Infof("Append tfjob condition error: %v", err)
+			return true, err
+		}
+		return true, nil
+	}
+
+	msg = fmt.Sprintf("TFJob %s/%s has failed because %s", tfjob.Namespace, tfjob.Name, msg)
+	tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobPendingTimeoutReason, msg)
+	if jobStatus.CompletionTime == nil {
+		now := metav1.Now()
+		jobStatus.CompletionTime = &now
+	}
+	if err := commonutil.UpdateJobConditions(jobStatus, commonv1.JobFailed, tfJobPendingTimeoutReason, msg); err != nil {
+		logger.Infof("Append tfjob condition error: %v", err)
+		return true, err
+	}
+	tfJobsFailureCount.WithLabelValues(tfjob.Namespace).Inc()
+	return true, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/common/pkg/controller.v1/control"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobfake "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned/fake"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestPodPendingReason(t *testing.T) {
+	testCases := map[string]struct {
+		status   v1.PodStatus
+		expected string
+	}{
+		"no status": {
+			status:   v1.PodStatus{Phase: v1.PodPending},
+			expected: "Pending",
+		},
+		"unschedulable": {
+			status: v1.PodStatus{
+				Phase: v1.PodPending,
+				Conditions: []v1.PodCondition{{
+					Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable,
+				}},
+			},
+			expected: "Unschedulable",
+		},
+		"image pull back off": {
+			status: v1.PodStatus{
+				Phase: v1.PodPending,
+				ContainerStatuses: []v1.ContainerStatus{{
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+				}},
+			},
+			expected: "ImagePullBackOff",
+		},
+		"init container config error": {
+			status: v1.PodStatus{
+				Phase: v1.PodPending,
+				InitContainerStatuses: []v1.ContainerStatus{{
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CreateContainerConfigError"}},
+				}},
+				ContainerStatuses: []v1.ContainerStatus{{
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "PodInitializing"}},
+				}},
+			},
+			expected: "CreateContainerConfigError",
+		},
+	}
+	for name, tc := range testCases {
+		pod := &v1.Pod{Status: tc.status}
+		if reason, _ := podPendingReason(pod); reason != tc.expected {
+			t.Errorf("%s: expected reason %s, got %s", name, tc.expected, reason)
+		}
+	}
+}
+
+func TestPendingTimeout(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+
+	testCases := map[string]struct {
+		action            tfv1.PendingTimeoutAction
+		age               time.Duration
+		expectedCondition commonv1.JobConditionType
+	}{
+		"not expired": {
+			action: tfv1.PendingTimeoutActionFail,
+			age:    time.Minute,
+		},
+		"fail": {
+			action:            tfv1.PendingTimeoutActionFail,
+			age:               time.Hour,
+			expectedCondition: commonv1.JobFailed,
+		},
+		"suspend": {
+			action:            tfv1.PendingTimeoutActionSuspend,
+			age:               time.Hour,
+			expectedCondition: tfv1.JobSuspended,
+		},
+	}
+	for name, tc := range testCases {
+		tfJob := testutil.NewTFJob(2, 0)
+		tfJob.Spec.PendingTimeoutPolicy = &tfv1.PendingTimeoutPolicy{TimeoutSeconds: 600, Action: tc.action}
+		tfJobClientSet := tfjobfake.NewSimpleClientset(tfJob.DeepCopy())
+		ctr, _, _ := newTFController(config, kubeClientSet,
+			volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+		ctr.PodControl = &control.FakePodControl{}
+
+		rtype := tfv1.TFReplicaTypeWorker
+		pods := testutil.NewPodList(2, v1.PodPending, tfJob, testutil.LabelWorker, 0)
+		for _, pod := range pods {
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-tc.age))
+			pod.Status.Conditions = []v1.PodCondition{{
+				Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable,
+			}}
+		}
+		err := ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods, rtype,
+			tfJob.Spec.TFReplicaSpecs[rtype], tfJob.Spec.TFReplicaSpecs)
+		if err != nil {
+			t.Errorf("%s: failed to reconcile pods: %v", name, err)
+		}
+
+		// The replicas after the expired one are still recorded.
+		if getReplicaIndexStatus(&tfJob.Status, rtype, 1) == nil {
+			t.Errorf("%s: expected worker-1 to be recorded, got %v", name, tfJob.Status.ReplicaIndexStatuses)
+		}
+
+		if tc.expectedCondition == "" {
+			if len(tfJob.Status.Conditions) != 0 {
+				t.Errorf("%s: expected no condition, got %v", name, tfJob.Status.Conditions)
+			}
+			continue
+		}
+		found := false
+		for _, condition := range tfJob.Status.Conditions {
+			if condition.Type == tc.expectedCondition && condition.Status == v1.ConditionTrue {
+				found = true
+				if condition.Reason != tfJobPendingTimeoutReason || !strings.Contains(condition.Message, "Unschedulable") {
+					t.Errorf("%s: expected the condition to quote the pending reason, got %v", name, condition)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected condition %s, got %v", name, tc.expectedCondition, tfJob.Status.Conditions)
+		}
+		if tc.action == tfv1.PendingTimeoutActionSuspend {
+			updated, err := tfJobClientSet.KubeflowV1().TFJobs(tfJob.Namespace).Get(tfJob.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("%s: failed to get the tfjob: %v", name, err)
+			}
+			if updated.Spec.Suspend != nil {
+				t.Errorf("%s: expected the spec to be unchanged, got suspend %v", name, *updated.Spec.Suspend)
+			}
+			if !isSuspended(tfJob) {
+				t.Errorf("%s: expected the tfjob to be suspended", name)
+			}
+		}
+	}
+}
+
+func TestPendingTimeoutSuspendAfterResume(t *testing.T) {
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJob := testutil.NewTFJob(1, 0)
+	tfJob.Generation = 2
+	tfJob.Spec.PendingTimeoutPolicy = &tfv1.PendingTimeoutPolicy{
+		TimeoutSeconds: 600, Action: tfv1.PendingTimeoutActionSuspend,
+	}
+	ctr, _, _ := newTFController(config, kubeclientset.NewForConfigOrDie(config),
+		nil, tfjobfake.NewSimpleClientset(), 0, options.ServerOption{})
+
+	pod := testutil.NewPod(tfJob, testutil.LabelWorker, 0)
+	pod.Status.Phase = v1.PodPending
+	pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	expired, err := ctr.checkPendingTimeout(tfJob, &tfJob.Status.JobStatus, tfv1.TFReplicaTypeWorker, pod)
+	if err != nil || !expired {
+		t.Fatalf("Expected the pending timeout to expire, got %v", err)
+	}
+	if !isSuspended(tfJob) {
+		t.Fatalf("Expected the tfjob to be suspended")
+	}
+
+	// The tfjob is resumed once its spec is updated, e.g. spec.suspend is set to false.
+	suspend := false
+	tfJob.Spec.Suspend = &suspend
+	tfJob.Generation = 3
+	if isSuspended(tfJob) {
+		t.Fatalf("Expected the tfjob to be resumed")
+	}
+	if err := ctr.resumeTFJob(tfJob); err != nil {
+		t.Fatalf("Failed to resume the tfjob: %v", err)
+	}
+
+	// The pending timeout suspends the tfjob again.
+	expired, err = ctr.checkPendingTimeout(tfJob, &tfJob.Status.JobStatus, tfv1.TFReplicaTypeWorker, pod)
+	if err != nil || !expired {
+		t.Fatalf("Expected the pending timeout to expire again, got %v", err)
+	}
+	if !isSuspended(tfJob) || tfJob.Status.SuspendedGeneration != 3 {
+		t.Errorf("Expected the tfjob to be suspended again at generation 3, got %v", tfJob.Status)
+	}
+}
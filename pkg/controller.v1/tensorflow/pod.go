@@ -16,9 +16,13 @@
 package tensorflow
 
 import (
+	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
@@ -29,17 +33,28 @@ import (
 	"github.com/kubeflow/common/pkg/controller.v1/common"
 	"github.com/kubeflow/common/pkg/controller.v1/expectation"
 	commonutil "github.com/kubeflow/common/pkg/util"
-	train_util "github.com/kubeflow/common/pkg/util/train"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 const (
-	// gang scheduler name.
-	gangSchedulerName = "volcano"
 	// tfConfig is the environment variable name of TensorFlow cluster spec.
 	tfConfig = "TF_CONFIG"
+	// tfConfigFile is the environment variable name of the file containing
+	// TensorFlow cluster spec for ClusterSpecModePodIP.
+	tfConfigFile = "TF_CONFIG_FILE"
+	// tfConfigAnnotation is the pod annotation containing TensorFlow cluster
+	// spec for ClusterSpecModePodIP, which is projected into tfConfigFile.
+	tfConfigAnnotation = "kubeflow.org/tf-config"
+	// tfConfigVolumeName is the name of the volume containing tfConfigFile.
+	tfConfigVolumeName = "tf-config"
+	// tfConfigMountPath is the directory of tfConfigFile in the container.
+	tfConfigMountPath = "/etc/tf-config"
+	// tfConfigInitContainerName is the name of the init container waiting for
+	// tfConfigAnnotation or the TF_CONFIG ConfigMap to be written for
+	// ClusterSpecModePodIP.
+	tfConfigInitContainerName = "wait-for-tf-config"
 	// exitedWithCodeReason is the normal reason when the pod is exited because of the exit code.
 	exitedWithCodeReason = "ExitedWithCode"
 	// podTemplateRestartPolicyReason is the warning reason when the restart
@@ -48,8 +63,6 @@ const (
 	// podTemplateSchedulerNameReason is the warning reason when other scheduler name is set
 	// in pod templates with gang-scheduling enabled
 	podTemplateSchedulerNameReason = "SettedPodTemplateSchedulerName"
-	// gangSchedulingPodGroupAnnotation is the annotation key used by batch schedulers
-	gangSchedulingPodGroupAnnotation = "scheduling.k8s.io/group-name"
 )
 
 var (
@@ -81,6 +94,8 @@ func (tc *TFController) ReconcilePods(
 	// Convert ReplicaType to lower string.
 	rt := strings.ToLower(string(rtype))
 	logger := commonutil.LoggerForJob(tfJob)
+	// Keep the pods of all types to generate the cluster spec from their addresses.
+	jobPods := pods
 	// Get all pods for the type rt.
 	pods, err := tc.FilterPodsForReplicaType(pods, rt)
 	if err != nil {
@@ -92,6 +107,26 @@ func (tc *TFController) ReconcilePods(
 	//worker0Completed := false
 
 	initializeReplicaStatuses(jobStatus, rtype)
+	// The replica indexes which are scaled down are no longer reported.
+	pruneReplicaIndexStatuses(&tfJob.Status, rtype, numReplicas)
+
+	// The pods are reconciled again once the last restart attempt is observed.
+	if tc.isRestartPending(tfJob) {
+		logger.Infof("Need to reconcile %s pods after restart attempt %d is observed", rt, tfJob.Status.RestartAttempt)
+		key, err := KeyFunc(tfJob)
+		if err != nil {
+			return err
+		}
+		tc.WorkQueue.AddRateLimited(key)
+		return nil
+	}
+
+	// The replicas of the later startup stages wait for the earlier ones to start.
+	var waiting []string
+	if isInOrderStartup(tfJob) && startupStage(rtype) > 0 {
+		waiting = waitingReplicaTypes(tfJob, jobPods, rtype)
+		tc.updateWaitingFor(tfJob, jobStatus, rtype, waiting)
+	}
 
 	// GetPodSlices will return enough information here to make decision to add/remove/update resources.
 	//
@@ -100,10 +135,29 @@ func (tc *TFController) ReconcilePods(
 	//
 	// If replica is 1, return a slice with size 3. [[0],[1],[2]], pod with replica-index 1 and 2 are out of range and will be deleted.
 	podSlices := tc.GetPodSlices(pods, numReplicas, logger)
+	pendingTimedOut := false
 	for index, podSlice := range podSlices {
 		if len(podSlice) > 1 {
 			logger.Warningf("We have too many pods for %s %d", rt, index)
 		} else if len(podSlice) == 0 {
+			// The tfjob is failed or suspended because a replica stayed pending for too long.
+			if pendingTimedOut {
+				continue
+			}
+			if len(waiting) > 0 {
+				logger.Infof("Need to create new pod: %s-%d after %v replica(s) start", rt, index, waiting)
+				continue
+			}
+			// Wait for the restart backoff of the replica index to pass.
+			if delay := tc.restartBackoffRemaining(tfJob, rtype, index); delay > 0 {
+				logger.Infof("Need to create new pod: %s-%d after restart backoff %v", rt, index, delay)
+				key, err := KeyFunc(tfJob)
+				if err != nil {
+					return err
+				}
+				tc.WorkQueue.AddAfter(key, delay)
+				continue
+			}
 			logger.Infof("Need to create new pod: %s-%d", rt, index)
 
 			// check if this replica is the master role
@@ -123,29 +177,73 @@ func (tc *TFController) ReconcilePods(
 				if err != nil {
 					return err
 				}
+				// The pod is scaled down and should not be counted in the replica statuses.
+				continue
+			}
+			// The pod of an earlier restart attempt is replaced by a new one.
+			if isStaleAttempt(tfJob, pod) {
+				// The pods are already deleted if all replicas are restarted by this sync.
+				if (pod.DeletionTimestamp == nil || pod.Status.Phase == v1.PodUnknown) && !tc.isRestartPending(tfJob) {
+					if err := tc.deletePod(tfJob, pod); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			// The replica killed by the infrastructure is recreated without counting it as failed.
+			if disrupted, err := tc.handleDisruption(tfJob, jobStatus, rtype, index, pod, jobPods); err != nil {
+				return err
+			} else if disrupted {
+				continue
+			}
+			if expired, err := tc.checkPendingTimeout(tfJob, jobStatus, rtype, pod); err != nil {
+				return err
+			} else if expired {
+				pendingTimedOut = true
 			}
 			// Get the exit code of the container.
 			var exitCode int32 = 0xbeef // magic number
+			containerName := tfv1.GetContainerName(&tfJob.Spec, pod.Annotations)
 			for _, status := range pod.Status.ContainerStatuses {
 				state := status.State
-				if status.Name == tc.GetDefaultContainerName() && state.Terminated != nil {
+				if status.Name == containerName && state.Terminated != nil {
 					exitCode = state.Terminated.ExitCode
 					logger.Infof("Pod: %v.%v exited with code %v", pod.Namespace, pod.Name, exitCode)
-					tc.Recorder.Eventf(tfJob, v1.EventTypeNormal, exitedWithCodeReason, "Pod: %v.%v exited with code %v", pod.Namespace, pod.Name, exitCode)
+					if state.Terminated.Reason == oomKilledReason {
+						tc.Recorder.Eventf(tfJob, v1.EventTypeWarning, oomKilledReason, "Pod: %v.%v was killed because it ran out of memory (exit code %v)", pod.Namespace, pod.Name, exitCode)
+					} else {
+						tc.Recorder.Eventf(tfJob, v1.EventTypeNormal, exitedWithCodeReason, "Pod: %v.%v exited with code %v", pod.Namespace, pod.Name, exitCode)
+					}
 				}
 			}
+			// Recreate the replica with more memory if it ran out of memory.
+			recreated, err := tc.handleOOMKilled(tfJob, jobStatus, rtype, index, pod, containerName)
+			if err != nil {
+				return err
+			}
 			// Check if the pod is retryable.
-			if spec.RestartPolicy == commonv1.RestartPolicyExitCode {
-				if pod.Status.Phase == v1.PodFailed && train_util.IsRetryableExitCode(exitCode) {
-					logger.Infof("Need to restart the pod: %v.%v", pod.Namespace, pod.Name)
-					if err := tc.PodControl.DeletePod(pod.Namespace, pod.Name, tfJob); err != nil {
-						return err
+			if spec.RestartPolicy == commonv1.RestartPolicyExitCode && !recreated {
+				retryable, rule := isRetryableExitCode(tfJob, rtype, exitCode)
+				if pod.Status.Phase == v1.PodFailed && retryable {
+					msg := fmt.Sprintf("TFJob %s is restarting because %s replica(s) failed with exit code %d, matching %s.",
+						tfJob.Name, rtype, exitCode, rule)
+					if isJobRestartScope(tfJob) {
+						// The replicas of synchronous strategies only recover together.
+						if err := tc.restartAllReplicas(tfJob, jobPods); err != nil {
+							return err
+						}
+						msg = fmt.Sprintf("TFJob %s is restarting all replicas as attempt %d because %s replica(s) failed with exit code %d, matching %s.",
+							tfJob.Name, tfJob.Status.RestartAttempt, rtype, exitCode, rule)
+					} else {
+						logger.Infof("Need to restart the pod: %v.%v", pod.Namespace, pod.Name)
+						if err := tc.PodControl.DeletePod(pod.Namespace, pod.Name, tfJob); err != nil {
+							return err
+						}
 					}
+					recordReplicaRestart(&tfJob.Status, rtype, index)
 
 					// with common library framework, we have to handle restart status here
 					// or we won't know which replica has been restarted in updateJobStatus after reconciling all replicas
-					msg := fmt.Sprintf("TFJob %s is restarting because %s replica(s) failed.",
-						tfJob.Name, rtype)
 					tc.Recorder.Event(tfJob, corev1.EventTypeWarning, tfJobRestartingReason, msg)
 					err := commonutil.UpdateJobConditions(jobStatus, commonv1.JobRestarting, tfJobRestartingReason, msg)
 					if err != nil {
@@ -153,15 +251,87 @@ func (tc *TFController) ReconcilePods(
 						return err
 					}
 					tfJobsRestartCount.WithLabelValues(tfJob.Namespace).Inc()
+				} else if pod.Status.Phase == v1.PodFailed {
+					logger.Infof("Pod: %v.%v is not restarted because exit code %d matches %s",
+						pod.Namespace, pod.Name, exitCode, rule)
 				}
 			}
 
+			recordReplicaPod(&tfJob.Status, rtype, index, pod, containerName)
 			updateJobReplicaStatuses(jobStatus, rtype, pod)
 		}
 	}
+	if pendingTimedOut {
+		return nil
+	}
+	return tc.updateTFConfigAnnotations(tfJob, jobPods, pods, rt)
+}
+
+// updateTFConfigAnnotations sets TF_CONFIG in the annotation of the pods of the
+// type rt for ClusterSpecModePodIP, once the addresses of all pods are known.
+func (tc *TFController) updateTFConfigAnnotations(tfjob *tfv1.TFJob, jobPods, pods []*v1.Pod, rt string) error {
+	if !isPodIPClusterSpec(tfjob) || isConfigMapTFConfig(tfjob) || !needsTFConfig(tfjob, rt) {
+		return nil
+	}
+	cluster, ready, err := genClusterSpecFromPods(tfjob, jobPods)
+	if err != nil || !ready {
+		return err
+	}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		tfConfigStr, err := genTFConfigJSONStrWithCluster(tfjob, cluster, rt, pod.Labels[tfReplicaIndexLabel])
+		if err != nil {
+			return err
+		}
+		if pod.Annotations[tfConfigAnnotation] == tfConfigStr {
+			continue
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]string{tfConfigAnnotation: tfConfigStr},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		commonutil.LoggerForReplica(tfjob, rt).Infof("Set TF_CONFIG of pod %s/%s", pod.Namespace, pod.Name)
+		if err := tc.PodControl.PatchPod(pod.Namespace, pod.Name, patch); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// restartBackoff returns the delay before recreating a replica which has been
+// restarted the given times. It doubles on every restart up to restartBackoffMax.
+func (tc *TFController) restartBackoff(restarts int32) time.Duration {
+	if tc.restartBackoffBase <= 0 || restarts <= 0 {
+		return 0
+	}
+	delay := tc.restartBackoffBase
+	for i := int32(1); i < restarts; i++ {
+		delay *= 2
+		if tc.restartBackoffMax > 0 && delay >= tc.restartBackoffMax {
+			return tc.restartBackoffMax
+		}
+	}
+	if tc.restartBackoffMax > 0 && delay > tc.restartBackoffMax {
+		return tc.restartBackoffMax
+	}
+	return delay
+}
+
+// restartBackoffRemaining returns how long to wait before recreating the replica index.
+func (tc *TFController) restartBackoffRemaining(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, index int) time.Duration {
+	status := getReplicaIndexStatus(&tfJob.Status, rtype, index)
+	if status == nil || status.LastRestartTime == nil {
+		return 0
+	}
+	return time.Until(status.LastRestartTime.Add(tc.restartBackoff(status.Restarts)))
+}
+
 // createNewPod creates a new pod for the given index and type.
 func (tc *TFController) createNewPod(tfjob *tfv1.TFJob, rt, index string, spec *commonv1.ReplicaSpec, masterRole bool,
 	replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) error {
@@ -201,10 +371,14 @@ func (tc *TFController) createNewPod(tfjob *tfv1.TFJob, rt, index string, spec *
 	for key, value := range labels {
 		podTemplate.Labels[key] = value
 	}
+	setRestartAttemptLabel(tfjob, podTemplate)
 
 	if err := tc.SetClusterSpec(tfjob, podTemplate, rt, index); err != nil {
 		return err
 	}
+	setReplicaEnv(tfjob, podTemplate, rt, index)
+	setReplicaMemory(tfjob, podTemplate, rt, index)
+	setPodHostname(tfjob, podTemplate)
 
 	// Submit a warning event if the user specifies restart policy for
 	// the pod template. We recommend to set it from the replica level.
@@ -217,22 +391,8 @@ func (tc *TFController) createNewPod(tfjob *tfv1.TFJob, rt, index string, spec *
 
 	// if gang-scheduling is enabled:
 	// 1. if user has specified other scheduler, we report a warning without overriding any fields.
-	// 2. if no SchedulerName is set for pods, then we set the SchedulerName to "kube-batch".
-	if tc.Config.EnableGangScheduling {
-		if isNonGangSchedulerSet(replicas) {
-			errMsg := "Another scheduler is specified when gang-scheduling is enabled and it will not be overwritten"
-			logger.Warning(errMsg)
-			tc.Recorder.Event(tfjob, v1.EventTypeWarning, podTemplateSchedulerNameReason, errMsg)
-		} else {
-			podTemplate.Spec.SchedulerName = gangSchedulerName
-		}
-
-		if podTemplate.Annotations == nil {
-			podTemplate.Annotations = map[string]string{}
-		}
-		podTemplate.Annotations[gangSchedulingPodGroupAnnotation] = tfjob.GetName()
-		podTemplate.Annotations[volcanoTaskSpecKey] = rt
-	}
+	// 2. if no SchedulerName is set for pods, then we set the SchedulerName to the gang scheduler.
+	tc.setPodGangScheduling(tfjob, podTemplate, rt)
 
 	err = tc.PodControl.CreatePodsWithControllerRef(tfjob.Namespace, podTemplate, tfjob, controllerRef)
 	if err != nil && errors.IsTimeout(err) {
@@ -262,12 +422,20 @@ func (tc *TFController) SetClusterSpec(job interface{}, podTemplate *v1.PodTempl
 		return fmt.Errorf("%v is not a type of MXJob", tfjob)
 	}
 
-	// Do not set TF_CONFIG for local training jobs.
-	if !isDistributed(tfjob) {
+	if !needsTFConfig(tfjob, rtype) {
+		return nil
+	}
+	// The cluster spec is written into the ConfigMap, see reconcileTFConfigMap.
+	if isConfigMapTFConfig(tfjob) {
+		return setTFConfigConfigMap(tfjob, podTemplate, rtype, index)
+	}
+	// The addresses of the pods are not known yet, see updateTFConfigAnnotations.
+	if isPodIPClusterSpec(tfjob) {
+		setTFConfigFile(tfjob, podTemplate)
 		return nil
 	}
 	// Generate TF_CONFIG JSON string.
-	tfConfigStr, err := genTFConfigJSONStr(tfjob, rtype, index)
+	tfConfigStr, err := genTFConfigJSONStr(tfjob, rtype, index, tc.getClusterDomain(tfjob))
 	if err != nil {
 		return err
 	}
@@ -276,8 +444,9 @@ func (tc *TFController) SetClusterSpec(job interface{}, podTemplate *v1.PodTempl
 		return nil
 	}
 	// Add TF_CONFIG environment variable to tensorflow container in the pod.
+	containerName := tfv1.GetContainerName(&tfjob.Spec, podTemplate.Annotations)
 	for i := range podTemplate.Spec.Containers {
-		if podTemplate.Spec.Containers[i].Name == tfv1.DefaultContainerName {
+		if podTemplate.Spec.Containers[i].Name == containerName {
 			if len(podTemplate.Spec.Containers[i].Env) == 0 {
 				podTemplate.Spec.Containers[i].Env = make([]v1.EnvVar, 0)
 			}
@@ -291,21 +460,138 @@ func (tc *TFController) SetClusterSpec(job interface{}, podTemplate *v1.PodTempl
 	return nil
 }
 
+// setReplicaEnv sets the standard environment variables of the replica, such as
+// tfv1.EnvJobName, in all containers and init containers of the pod, whether the
+// tfjob is distributed or not. The variables set by the user are kept.
+func setReplicaEnv(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec, rt, index string) {
+	env := []v1.EnvVar{
+		{Name: tfv1.EnvJobName, Value: tfjob.Name},
+		{Name: tfv1.EnvJobNamespace, Value: tfjob.Namespace},
+		{Name: tfv1.EnvReplicaType, Value: rt},
+		{Name: tfv1.EnvReplicaIndex, Value: index},
+	}
+	// Sort the replica types to keep the order of the variables stable.
+	rtypes := make([]string, 0, len(tfjob.Spec.TFReplicaSpecs))
+	for rtype := range tfjob.Spec.TFReplicaSpecs {
+		rtypes = append(rtypes, string(rtype))
+	}
+	sort.Strings(rtypes)
+	worldSize := int32(0)
+	for _, rtype := range rtypes {
+		replicas := int32(1)
+		if spec := tfjob.Spec.TFReplicaSpecs[commonv1.ReplicaType(rtype)]; spec != nil && spec.Replicas != nil {
+			replicas = *spec.Replicas
+		}
+		if isClusterSpecMember(tfjob, commonv1.ReplicaType(rtype)) {
+			worldSize += replicas
+		}
+		env = append(env, v1.EnvVar{
+			Name:  fmt.Sprintf(tfv1.EnvReplicasFormat, strings.ToUpper(rtype)),
+			Value: strconv.Itoa(int(replicas)),
+		})
+	}
+	env = append(env, v1.EnvVar{Name: tfv1.EnvWorldSize, Value: strconv.Itoa(int(worldSize))})
+
+	setEnv := func(container *v1.Container) {
+		for _, e := range env {
+			found := false
+			for _, existing := range container.Env {
+				if existing.Name == e.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				container.Env = append(container.Env, e)
+			}
+		}
+	}
+	for i := range podTemplate.Spec.InitContainers {
+		setEnv(&podTemplate.Spec.InitContainers[i])
+	}
+	for i := range podTemplate.Spec.Containers {
+		setEnv(&podTemplate.Spec.Containers[i])
+	}
+}
+
+// needsTFConfig returns if TF_CONFIG is set for the replica type. Do not set
+// TF_CONFIG for local training jobs. The replicas out of the cluster spec,
+// e.g. Evaluator, still need TF_CONFIG to know their task.
+func needsTFConfig(tfjob *tfv1.TFJob, rtype string) bool {
+	return isDistributed(tfjob) || !isClusterSpecMember(tfjob, commonv1.ReplicaType(rtype))
+}
+
+// setTFConfigFile projects the TF_CONFIG annotation of the pod into a file in
+// the tensorflow container, and sets its path in TF_CONFIG_FILE. TF_CONFIG is
+// not set, since it is only known once all pods have their addresses, thus the
+// training code has to read it from the file, see docs/tf-config.md. An init
+// container running the image of the tensorflow container waits for the
+// annotation, so that the file is written when the tensorflow container starts.
+func setTFConfigFile(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec) {
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, v1.Volume{
+		Name: tfConfigVolumeName,
+		VolumeSource: v1.VolumeSource{
+			DownwardAPI: &v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{{
+					Path: tfConfig,
+					FieldRef: &v1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", tfConfigAnnotation),
+					},
+				}},
+			},
+		},
+	})
+	mount := v1.VolumeMount{
+		Name:      tfConfigVolumeName,
+		MountPath: tfConfigMountPath,
+		ReadOnly:  true,
+	}
+	filePath := path.Join(tfConfigMountPath, tfConfig)
+	containerName := tfv1.GetContainerName(&tfjob.Spec, podTemplate.Annotations)
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Name == containerName {
+			container.VolumeMounts = append(container.VolumeMounts, mount)
+			container.Env = append(container.Env, v1.EnvVar{
+				Name:  tfConfigFile,
+				Value: filePath,
+			})
+			podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers,
+				genTFConfigInitContainer(container, mount, filePath))
+			break
+		}
+	}
+}
+
+// genTFConfigInitContainer returns the init container which runs the image of
+// the tensorflow container and waits for the file containing the cluster spec
+// to be written, for ClusterSpecModePodIP.
+func genTFConfigInitContainer(container *v1.Container, mount v1.VolumeMount, filePath string) v1.Container {
+	return v1.Container{
+		Name:            tfConfigInitContainerName,
+		Image:           container.Image,
+		ImagePullPolicy: container.ImagePullPolicy,
+		Command:         []string{"sh", "-c", fmt.Sprintf("until [ -s %s ]; do sleep 1; done", filePath)},
+		VolumeMounts:    []v1.VolumeMount{mount},
+	}
+}
+
 // isDistributed returns if the TFJob is a distributed training job.
 // Ref https://github.com/kubeflow/tf-operator/issues/1078.
 func isDistributed(tfjob *tfv1.TFJob) bool {
 	replicas := tfjob.Spec.TFReplicaSpecs
 	distributionCount := 0
 	allTypes := []commonv1.ReplicaType{
+		tfv1.TFReplicaTypeCoordinator,
 		tfv1.TFReplicaTypeChief,
 		tfv1.TFReplicaTypeEval,
 		tfv1.TFReplicaTypeMaster,
 		tfv1.TFReplicaTypePS,
 		tfv1.TFReplicaTypeWorker,
 	}
-	// Check if there is only one replica.
+	// Check if there is only one replica in the cluster spec.
 	for _, typ := range allTypes {
-		if replicas[typ] != nil {
+		if replicas[typ] != nil && isClusterSpecMember(tfjob, typ) {
 			if replicas[typ].Replicas == nil {
 				distributionCount++
 			} else {
@@ -344,11 +630,12 @@ func (tc *TFController) getPodSlices(tfjob *tfv1.TFJob, replicasNum *int32) ([][
 	return podSlices, nil
 }
 
-func getContainerExitCode(pod *v1.Pod) int32 {
+func getContainerExitCode(tfjob *tfv1.TFJob, pod *v1.Pod) int32 {
 	var exitCode int32 = 0xbeef // magic number
+	containerName := tfv1.GetContainerName(&tfjob.Spec, pod.Annotations)
 	for _, status := range pod.Status.ContainerStatuses {
 		state := status.State
-		if status.Name == tfv1.DefaultContainerName && state.Terminated != nil {
+		if status.Name == containerName && state.Terminated != nil {
 			exitCode = state.Terminated.ExitCode
 		}
 	}
@@ -369,7 +656,7 @@ func (tc *TFController) IsWorker0Completed(tfjob *tfv1.TFJob, replicas map[commo
 	for index, podSlice := range podSlices {
 		if len(podSlice) == 1 {
 			pod := podSlice[0]
-			exitCode := getContainerExitCode(pod)
+			exitCode := getContainerExitCode(tfjob, pod)
 			if index == 0 && exitCode == 0 && pod.Status.Phase == v1.PodSucceeded {
 				worker0Completed = true
 			}
@@ -378,11 +665,18 @@ func (tc *TFController) IsWorker0Completed(tfjob *tfv1.TFJob, replicas map[commo
 	return worker0Completed, nil
 }
 
-func isNonGangSchedulerSet(replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) bool {
-	for _, spec := range replicas {
-		if spec.Template.Spec.SchedulerName != "" && spec.Template.Spec.SchedulerName != gangSchedulerName {
-			return true
-		}
+// isWorker0Failed returns true if the pod of worker 0 has failed.
+func (tc *TFController) isWorker0Failed(tfjob *tfv1.TFJob, replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) (bool, error) {
+	spec, ok := replicas[tfv1.TFReplicaTypeWorker]
+	if !ok {
+		return false, nil
+	}
+	podSlices, err := tc.getPodSlices(tfjob, spec.Replicas)
+	if err != nil {
+		return false, err
+	}
+	if len(podSlices) == 0 || len(podSlices[0]) != 1 {
+		return false, nil
 	}
-	return false
+	return podSlices[0][0].Status.Phase == v1.PodFailed, nil
 }
@@ -17,9 +17,9 @@ package tensorflow
 
 import (
 	"fmt"
-	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	kubeclientset "k8s.io/client-go/kubernetes"
@@ -69,6 +69,8 @@ func TestAddPod(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 	tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 
 	stopCh := make(chan struct{})
@@ -137,6 +139,8 @@ func TestExpectation(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 
 	ctr.PodControl = &control.FakePodControl{}
 	tfJob := testutil.NewTFJob(2, 1)
@@ -196,6 +200,8 @@ func TestExpectationWithError(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 
 	ctr.PodControl = &control.FakePodControl{}
 	tfJob := testutil.NewTFJob(2, 1)
@@ -264,8 +270,7 @@ func TestClusterSpec(t *testing.T) {
 			rt:                  "worker",
 			index:               "0",
 			customClusterDomain: "tf.training.io",
-			expectedClusterSpec: `{"cluster":{"evaluator":["` + testutil.TestTFJobName +
-				`-evaluator-0.ns3.svc.tf.training.io:2222"],"ps":["` + testutil.TestTFJobName +
+			expectedClusterSpec: `{"cluster":{"ps":["` + testutil.TestTFJobName +
 				`-ps-0.ns3.svc.tf.training.io:2222"],"worker":["` + testutil.TestTFJobName +
 				`-worker-0.ns3.svc.tf.training.io:2222"]},"task":{"type":"worker","index":0},"environment":"cloud"}`,
 		},
@@ -274,11 +279,79 @@ func TestClusterSpec(t *testing.T) {
 			rt:                  "worker",
 			index:               "0",
 			customClusterDomain: "",
-			expectedClusterSpec: `{"cluster":{"evaluator":["` + testutil.TestTFJobName +
-				`-evaluator-0.ns3.svc:2222"],"ps":["` + testutil.TestTFJobName +
+			expectedClusterSpec: `{"cluster":{"ps":["` + testutil.TestTFJobName +
 				`-ps-0.ns3.svc:2222"],"worker":["` + testutil.TestTFJobName +
 				`-worker-0.ns3.svc:2222"]},"task":{"type":"worker","index":0},"environment":"cloud"}`,
 		},
+		tc{
+			tfJob: func() *tfv1.TFJob {
+				tfJob := testutil.NewTFJobWithNamespace(1, 1, "ns7")
+				tfJob.Annotations = map[string]string{tfv1.ClusterDomainAnnotation: "cluster.example"}
+				return tfJob
+			}(),
+			rt:                  "worker",
+			index:               "0",
+			customClusterDomain: "tf.training.org",
+			expectedClusterSpec: `{"cluster":{"ps":["` + testutil.TestTFJobName +
+				`-ps-0.ns7.svc.cluster.example:2222"],"worker":["` + testutil.TestTFJobName +
+				`-worker-0.ns7.svc.cluster.example:2222"]},"task":{"type":"worker","index":0},"environment":"cloud"}`,
+		},
+		tc{
+			tfJob: func() *tfv1.TFJob {
+				tfJob := testutil.NewTFJobWithNamespace(1, 1, "ns8")
+				containerName := "trainer"
+				tfJob.Spec.ContainerName = &containerName
+				for _, spec := range tfJob.Spec.TFReplicaSpecs {
+					spec.Template.Annotations = map[string]string{tfv1.PortNameAnnotation: "trainer-port"}
+					spec.Template.Spec.Containers[0].Name = containerName
+					spec.Template.Spec.Containers[0].Ports = []v1.ContainerPort{{Name: "trainer-port", ContainerPort: 3333}}
+				}
+				return tfJob
+			}(),
+			rt:                  "worker",
+			index:               "0",
+			customClusterDomain: "",
+			expectedClusterSpec: `{"cluster":{"ps":["` + testutil.TestTFJobName +
+				`-ps-0.ns8.svc:3333"],"worker":["` + testutil.TestTFJobName +
+				`-worker-0.ns8.svc:3333"]},"task":{"type":"worker","index":0},"environment":"cloud"}`,
+		},
+		tc{
+			tfJob:               testutil.NewTFJobWithEvaluatorAndNamespace(1, 0, 1, "ns4"),
+			rt:                  "evaluator",
+			index:               "0",
+			customClusterDomain: "",
+			expectedClusterSpec: `{"cluster":{"worker":["` + testutil.TestTFJobName +
+				`-worker-0.ns4.svc:2222"]},"task":{"type":"evaluator","index":0},"environment":"cloud"}`,
+		},
+		tc{
+			tfJob: func() *tfv1.TFJob {
+				tfJob := testutil.NewTFJobWithCoordinator(1, 1)
+				tfJob.Namespace = "ns6"
+				return tfJob
+			}(),
+			rt:                  "coordinator",
+			index:               "0",
+			customClusterDomain: "",
+			expectedClusterSpec: `{"cluster":{"chief":["` + testutil.TestTFJobName +
+				`-coordinator-0.ns6.svc:2222"],"ps":["` + testutil.TestTFJobName +
+				`-ps-0.ns6.svc:2222"],"worker":["` + testutil.TestTFJobName +
+				`-worker-0.ns6.svc:2222"]},"task":{"type":"chief","index":0},"environment":"cloud"}`,
+		},
+		tc{
+			tfJob: func() *tfv1.TFJob {
+				tfJob := testutil.NewTFJobWithEvaluatorAndNamespace(1, 0, 1, "ns5")
+				tfJob.Spec.ClusterSpecMembership = map[commonv1.ReplicaType]bool{
+					tfv1.TFReplicaTypeEval: true,
+				}
+				return tfJob
+			}(),
+			rt:                  "worker",
+			index:               "0",
+			customClusterDomain: "",
+			expectedClusterSpec: `{"cluster":{"evaluator":["` + testutil.TestTFJobName +
+				`-evaluator-0.ns5.svc:2222"],"worker":["` + testutil.TestTFJobName +
+				`-worker-0.ns5.svc:2222"]},"task":{"type":"worker","index":0},"environment":"cloud"}`,
+		},
 	}
 	// Prepare the clientset and controller for the test.
 	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
@@ -310,9 +383,11 @@ func TestClusterSpec(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 
 	for _, c := range testCase {
-		os.Setenv(EnvCustomClusterDomain, c.customClusterDomain)
+		ctr.clusterDomain = c.customClusterDomain
 
 		podTemplate := c.tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.DeepCopy()
 
@@ -350,6 +425,96 @@ func TestClusterSpec(t *testing.T) {
 	}
 }
 
+func TestPodIPClusterSpec(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, _, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+	fakeServiceControl := &control.FakeServiceControl{}
+	ctr.ServiceControl = fakeServiceControl
+
+	tfJob := testutil.NewTFJob(2, 0)
+	mode := tfv1.ClusterSpecModePodIP
+	tfJob.Spec.ClusterSpecMode = &mode
+
+	// TF_CONFIG is read from the file projected from the annotation.
+	podTemplate := tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.DeepCopy()
+	if err := ctr.SetClusterSpec(tfJob, podTemplate, testutil.LabelWorker, "0"); err != nil {
+		t.Errorf("Failed to set cluster spec: %v", err)
+	}
+	env := podTemplate.Spec.Containers[0].Env
+	if len(env) != 1 || env[0].Name != tfConfigFile || env[0].Value != "/etc/tf-config/TF_CONFIG" {
+		t.Errorf("Expected %s to be set, got %v", tfConfigFile, env)
+	}
+	if len(podTemplate.Spec.Volumes) != 1 || podTemplate.Spec.Volumes[0].DownwardAPI == nil {
+		t.Errorf("Expected the downward API volume to be added, got %v", podTemplate.Spec.Volumes)
+	}
+	// The tensorflow container starts once TF_CONFIG is written.
+	initContainers := podTemplate.Spec.InitContainers
+	if len(initContainers) != 1 || initContainers[0].Name != tfConfigInitContainerName ||
+		initContainers[0].Image != podTemplate.Spec.Containers[0].Image || len(initContainers[0].VolumeMounts) != 1 {
+		t.Errorf("Expected the init container waiting for TF_CONFIG to be added, got %v", initContainers)
+	}
+
+	// No service is created.
+	rtype := tfv1.TFReplicaTypeWorker
+	if err := ctr.ReconcileServices(tfJob, nil, rtype, tfJob.Spec.TFReplicaSpecs[rtype]); err != nil {
+		t.Errorf("Failed to reconcile services: %v", err)
+	}
+	if len(fakeServiceControl.Templates) != 0 {
+		t.Errorf("Expected no service to be created, got %d", len(fakeServiceControl.Templates))
+	}
+
+	// TF_CONFIG is set once the addresses of all pods are known.
+	pod0 := testutil.NewPod(tfJob, testutil.LabelWorker, 0)
+	pod0.Status.PodIP = "10.0.0.1"
+	pod1 := testutil.NewPod(tfJob, testutil.LabelWorker, 1)
+	pods := []*v1.Pod{pod0, pod1}
+	if err := ctr.updateTFConfigAnnotations(tfJob, pods, pods, testutil.LabelWorker); err != nil {
+		t.Errorf("Failed to update TF_CONFIG: %v", err)
+	}
+	if len(fakePodControl.Patches) != 0 {
+		t.Errorf("Expected no TF_CONFIG before all addresses are known, got %d patches", len(fakePodControl.Patches))
+	}
+
+	pod1.Status.PodIP = "10.0.0.2"
+	if err := ctr.updateTFConfigAnnotations(tfJob, pods, pods, testutil.LabelWorker); err != nil {
+		t.Errorf("Failed to update TF_CONFIG: %v", err)
+	}
+	if len(fakePodControl.Patches) != 2 {
+		t.Fatalf("Expected TF_CONFIG of 2 pods to be set, got %d patches", len(fakePodControl.Patches))
+	}
+	expected := `{"metadata":{"annotations":{"kubeflow.org/tf-config":"{\"cluster\":{\"worker\":[\"10.0.0.1:2222\",\"10.0.0.2:2222\"]},\"task\":{\"type\":\"worker\",\"index\":1},\"environment\":\"cloud\"}"}}}`
+	if actual := string(fakePodControl.Patches[1]); actual != expected {
+		t.Errorf("Expected patch %s, got %s", expected, actual)
+	}
+}
+
 func TestIsDistributed(t *testing.T) {
 	type tc struct {
 		tfJob    *tfv1.TFJob
@@ -372,6 +537,20 @@ func TestIsDistributed(t *testing.T) {
 			tfJob:    testutil.NewTFJobWithChief(1, 0),
 			expected: true,
 		},
+		{
+			tfJob:    testutil.NewTFJobWithEvaluator(1, 0, 1),
+			expected: false,
+		},
+		{
+			tfJob: func() *tfv1.TFJob {
+				tfJob := testutil.NewTFJobWithEvaluator(1, 0, 1)
+				tfJob.Spec.ClusterSpecMembership = map[commonv1.ReplicaType]bool{
+					tfv1.TFReplicaTypeEval: true,
+				}
+				return tfJob
+			}(),
+			expected: true,
+		},
 	}
 	for _, c := range testCase {
 		actual := isDistributed(c.tfJob)
@@ -439,6 +618,48 @@ func TestRestartPolicy(t *testing.T) {
 	}
 }
 
+func TestReplicaEnv(t *testing.T) {
+	tfJob := testutil.NewTFJob(2, 1)
+	// The Evaluator is not counted in the world size.
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeEval] = tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypePS].DeepCopy()
+	podTemplate := tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.DeepCopy()
+	podTemplate.Spec.InitContainers = []v1.Container{{Name: "init"}}
+	podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, v1.Container{
+		Name: "sidecar",
+		Env:  []v1.EnvVar{{Name: tfv1.EnvJobName, Value: "custom"}},
+	})
+	setReplicaEnv(tfJob, podTemplate, "worker", "1")
+
+	expected := map[string]string{
+		tfv1.EnvJobName:            testutil.TestTFJobName,
+		tfv1.EnvJobNamespace:       tfJob.Namespace,
+		tfv1.EnvReplicaType:        "worker",
+		tfv1.EnvReplicaIndex:       "1",
+		tfv1.EnvWorldSize:          "3",
+		"TFJOB_WORKER_REPLICAS":    "2",
+		"TFJOB_PS_REPLICAS":        "1",
+		"TFJOB_EVALUATOR_REPLICAS": "1",
+	}
+	containers := append(podTemplate.Spec.InitContainers, podTemplate.Spec.Containers...)
+	for _, container := range containers {
+		env := map[string]string{}
+		for _, e := range container.Env {
+			env[e.Name] = e.Value
+		}
+		for name, value := range expected {
+			if name == tfv1.EnvJobName && container.Name == "sidecar" {
+				value = "custom"
+			}
+			if env[name] != value {
+				t.Errorf("Container %s: expected %s=%s, got %s", container.Name, name, value, env[name])
+			}
+		}
+		if len(env) != len(container.Env) {
+			t.Errorf("Container %s: duplicate environment variables %v", container.Name, container.Env)
+		}
+	}
+}
+
 func TestExitCode(t *testing.T) {
 	// Prepare the clientset and controller for the test.
 	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
@@ -472,6 +693,8 @@ func TestExitCode(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 	tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 	podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
 
@@ -508,7 +731,7 @@ func TestExitCode(t *testing.T) {
 	if err := podIndexer.Add(pod); err != nil {
 		t.Errorf("%s: unexpected error when adding pod %v", tfJob.Name, err)
 	}
-	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status, &tfJob.Spec.RunPolicy)
+	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status.JobStatus, &tfJob.Spec.RunPolicy)
 	// _, err = ctr.syncTFJob(testutil.GetKey(tfJob, t))
 	// if err != nil {
 	// 	t.Errorf("%s: unexpected error when syncing jobs %v", tfJob.Name, err)
@@ -523,9 +746,95 @@ func TestExitCode(t *testing.T) {
 	if !found {
 		t.Errorf("Failed to delete pod %s", pod.Name)
 	}
+	status := getReplicaIndexStatus(&tfJob.Status, tfv1.TFReplicaTypeWorker, 0)
+	if status == nil || status.Restarts != 1 || status.LastRestartTime == nil {
+		t.Errorf("Expected the restart of %s to be recorded, got %v", pod.Name, status)
+	}
 	close(stopCh)
 }
 
+func TestRestartBackoff(t *testing.T) {
+	ctr := &TFController{
+		restartBackoffBase: 10 * time.Second,
+		restartBackoffMax:  time.Minute,
+	}
+	backoffs := map[int32]time.Duration{
+		0:   0,
+		1:   10 * time.Second,
+		2:   20 * time.Second,
+		3:   40 * time.Second,
+		4:   time.Minute,
+		100: time.Minute,
+	}
+	for restarts, expected := range backoffs {
+		if actual := ctr.restartBackoff(restarts); actual != expected {
+			t.Errorf("Expected backoff %v after %d restarts, got %v", expected, restarts, actual)
+		}
+	}
+
+	ctr.restartBackoffBase = 0
+	if actual := ctr.restartBackoff(3); actual != 0 {
+		t.Errorf("Expected no backoff when it is disabled, got %v", actual)
+	}
+}
+
+func TestRestartBackoffDelaysPodCreation(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, _, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{
+			RestartBackoffBase: time.Hour,
+			RestartBackoffMax:  time.Hour,
+		})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+
+	tfJob := testutil.NewTFJob(2, 0)
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].RestartPolicy = commonv1.RestartPolicyExitCode
+	recordReplicaRestart(&tfJob.Status, tfv1.TFReplicaTypeWorker, 1)
+
+	rtype := tfv1.TFReplicaTypeWorker
+	err := ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, []*v1.Pod{}, rtype,
+		tfJob.Spec.TFReplicaSpecs[rtype], tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Errorf("Failed to reconcile pods: %v", err)
+	}
+
+	// Only the worker which has not been restarted is created.
+	if len(fakePodControl.Templates) != 1 {
+		t.Fatalf("Expected 1 pod to be created, got %d", len(fakePodControl.Templates))
+	}
+	if index := fakePodControl.Templates[0].Labels[tfReplicaIndexLabel]; index != "0" {
+		t.Errorf("Expected worker 0 to be created, got worker %s", index)
+	}
+	if ctr.WorkQueue.Len() != 0 {
+		t.Errorf("Expected the tfjob not to be requeued before the backoff passes")
+	}
+}
+
 // Test scaling down number of workers while training is running
 func TestScaleDown(t *testing.T) {
 	// Prepare the clientset and controller for the test.
@@ -561,6 +870,8 @@ func TestScaleDown(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 	tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 	podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
 
@@ -597,7 +908,7 @@ func TestScaleDown(t *testing.T) {
 		t.Errorf("%s: unexpected error when adding pod %v", tfJob.Name, err)
 	}
 
-	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status, &tfJob.Spec.RunPolicy)
+	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status.JobStatus, &tfJob.Spec.RunPolicy)
 	// _, err = ctr.syncTFJob(testutil.GetKey(tfJob, t))
 	// if err != nil {
 	// 	t.Errorf("%s: unexpected error when syncing jobs %v", tfJob.Name, err)
@@ -644,6 +955,8 @@ func TestScaleUp(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 	tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 	podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
 
@@ -671,7 +984,7 @@ func TestScaleUp(t *testing.T) {
 		t.Errorf("%s: unexpected error when adding pod %v", tfJob.Name, err)
 	}
 
-	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status, &tfJob.Spec.RunPolicy)
+	_ = ctr.ReconcileJobs(tfJob, tfJob.Spec.TFReplicaSpecs, tfJob.Status.JobStatus, &tfJob.Spec.RunPolicy)
 	// _, err = ctr.syncTFJob(testutil.GetKey(tfJob, t))
 	// if err != nil {
 	// 	t.Errorf("%s: unexpected error when syncing jobs %v", tfJob.Name, err)
@@ -804,10 +1117,12 @@ func TestIsWorker0Completed(t *testing.T) {
 		ctr.tfJobInformerSynced = testutil.AlwaysReady
 		ctr.PodInformerSynced = testutil.AlwaysReady
 		ctr.ServiceInformerSynced = testutil.AlwaysReady
+		ctr.configMapInformerSynced = testutil.AlwaysReady
+		ctr.pdbInformerSynced = testutil.AlwaysReady
 		podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
 
 		// only related to worker status
-		initializeReplicaStatuses(&tt.tfJob.Status, tfv1.TFReplicaTypeWorker)
+		initializeReplicaStatuses(&tt.tfJob.Status.JobStatus, tfv1.TFReplicaTypeWorker)
 		// set status and add pod to indexer
 		setStatusForTest(tt.tfJob, tfv1.TFReplicaTypeWorker, tt.workers[0], tt.workers[1], tt.workers[2], false, true, podIndexer, t)
 
@@ -0,0 +1,119 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubeflow/common/pkg/controller.v1/expectation"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+// isJobRestartScope returns if all replicas of the tfjob are restarted when
+// one of them fails with a retryable exit code.
+func isJobRestartScope(tfjob *tfv1.TFJob) bool {
+	return tfjob.Spec.RestartScope != nil && *tfjob.Spec.RestartScope == tfv1.RestartScopeJob
+}
+
+// podRestartAttempt returns the restart attempt the pod is created for.
+func podRestartAttempt(pod *v1.Pod) int32 {
+	attempt, err := strconv.Atoi(pod.Labels[tfRestartAttemptLabel])
+	if err != nil {
+		return 0
+	}
+	return int32(attempt)
+}
+
+// isStaleAttempt returns if the pod is created for an earlier restart attempt
+// of the tfjob, and has to be deleted before the replica is created again.
+func isStaleAttempt(tfjob *tfv1.TFJob, pod *v1.Pod) bool {
+	return isJobRestartScope(tfjob) && podRestartAttempt(pod) < tfjob.Status.RestartAttempt
+}
+
+// setRestartAttemptLabel labels the pod template with the current restart
+// attempt of the tfjob for the "Job" restart scope.
+func setRestartAttemptLabel(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec) {
+	if !isJobRestartScope(tfjob) {
+		return
+	}
+	podTemplate.Labels[tfRestartAttemptLabel] = strconv.Itoa(int(tfjob.Status.RestartAttempt))
+}
+
+// restartAllReplicas starts a new restart attempt of the tfjob, and deletes the
+// pods of all replicas, which are created again for the new attempt once the
+// attempt and the deletions are observed, see isRestartPending.
+func (tc *TFController) restartAllReplicas(tfjob *tfv1.TFJob, pods []*v1.Pod) error {
+	tfjobKey, err := KeyFunc(tfjob)
+	if err != nil {
+		return err
+	}
+	tfjob.Status.RestartAttempt++
+	tc.restartAttempts.Store(tfjobKey, tfjob.Status.RestartAttempt)
+	commonutil.LoggerForJob(tfjob).Infof("Restart all replicas as attempt %d", tfjob.Status.RestartAttempt)
+	return tc.deleteReplicaPods(tfjob, pods)
+}
+
+// isRestartPending returns true while the last restart attempt started by
+// restartAllReplicas is not observed yet, i.e. the tfjob from the shared
+// informer does not show the attempt in its status yet, or the deletions of
+// the pods of the earlier attempt are not observed yet. The pods are neither
+// deleted nor created meanwhile, as they would be labeled with a stale attempt.
+func (tc *TFController) isRestartPending(tfjob *tfv1.TFJob) bool {
+	if !isJobRestartScope(tfjob) {
+		return false
+	}
+	tfjobKey, err := KeyFunc(tfjob)
+	if err != nil {
+		return false
+	}
+	attempt, ok := tc.restartAttempts.Load(tfjobKey)
+	if !ok {
+		return false
+	}
+	if attempt.(int32) > tfjob.Status.RestartAttempt {
+		return true
+	}
+	for rtype := range tfjob.Spec.TFReplicaSpecs {
+		expectationPodsKey := expectation.GenExpectationPodsKey(tfjobKey, strings.ToLower(string(rtype)))
+		exp, found, err := tc.Expectations.GetExpectations(expectationPodsKey)
+		if err != nil || !found {
+			continue
+		}
+		if _, del := exp.GetExpectations(); del > 0 && !tc.Expectations.SatisfiedExpectations(expectationPodsKey) {
+			return true
+		}
+	}
+	tc.restartAttempts.Delete(tfjobKey)
+	return false
+}
+
+// forgetRestartAttempt forgets the restart attempt started by restartAllReplicas
+// if it is later than the persisted attempt, e.g. because the status of the
+// tfjob fails to be updated. Otherwise the shared informer never shows the
+// attempt, and isRestartPending keeps the pods from being reconciled.
+func (tc *TFController) forgetRestartAttempt(tfjob *tfv1.TFJob, persisted int32) {
+	tfjobKey, err := KeyFunc(tfjob)
+	if err != nil {
+		return
+	}
+	if attempt, ok := tc.restartAttempts.Load(tfjobKey); ok && attempt.(int32) > persisted {
+		commonutil.LoggerForJob(tfjob).Infof("Forget restart attempt %d which is not persisted", attempt)
+		tc.restartAttempts.Delete(tfjobKey)
+	}
+}
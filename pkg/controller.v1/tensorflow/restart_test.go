@@ -0,0 +1,245 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/common/pkg/controller.v1/control"
+	"github.com/kubeflow/common/pkg/controller.v1/expectation"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestJobRestartScope(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, _, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+
+	tfJob := testutil.NewTFJob(2, 1)
+	scope := tfv1.RestartScopeJob
+	tfJob.Spec.RestartScope = &scope
+	rtype := tfv1.TFReplicaTypeWorker
+	spec := tfJob.Spec.TFReplicaSpecs[rtype]
+	spec.RestartPolicy = commonv1.RestartPolicyExitCode
+
+	pods := testutil.NewPodList(1, v1.PodRunning, tfJob, testutil.LabelWorker, 1)
+	pods = append(pods, testutil.NewPodList(1, v1.PodRunning, tfJob, testutil.LabelPS, 0)...)
+	failed := testutil.NewPod(tfJob, testutil.LabelWorker, 0)
+	failed.Status.Phase = v1.PodFailed
+	failed.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name: tfv1.DefaultContainerName,
+		State: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{ExitCode: 130},
+		},
+	}}
+	pods = append(pods, failed)
+
+	// All replicas are deleted when a worker fails with a retryable exit code.
+	err := ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods, rtype, spec, tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if tfJob.Status.RestartAttempt != 1 {
+		t.Errorf("Expected restart attempt 1, got %d", tfJob.Status.RestartAttempt)
+	}
+	deleted := map[string]bool{}
+	for _, name := range fakePodControl.DeletePodName {
+		deleted[name] = true
+	}
+	var deletedNames []string
+	for name := range deleted {
+		deletedNames = append(deletedNames, name)
+	}
+	sort.Strings(deletedNames)
+	expected := []string{pods[0].Name, pods[1].Name, failed.Name}
+	sort.Strings(expected)
+	if !reflect.DeepEqual(deletedNames, expected) {
+		t.Errorf("Expected pods %v to be deleted, got %v", expected, deletedNames)
+	}
+	if !hasCondition(tfJob.Status.JobStatus, commonv1.JobRestarting) {
+		t.Errorf("Expected the tfjob to be restarting, got %v", tfJob.Status.Conditions)
+	}
+
+	// No pods are created while the tfjob from the shared informer shows the
+	// earlier attempt, as they would be labeled with it.
+	stale := tfJob.DeepCopy()
+	stale.Status.RestartAttempt = 0
+	fakePodControl.Clear()
+	err = ctr.ReconcilePods(stale, &stale.Status.JobStatus, nil, rtype, spec, stale.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.Templates) != 0 || len(fakePodControl.DeletePodName) != 0 {
+		t.Errorf("Expected no pods to be created or deleted with a stale status, got %v created and %v deleted",
+			len(fakePodControl.Templates), fakePodControl.DeletePodName)
+	}
+
+	// Nor until the deletions of the pods of the earlier attempt are observed.
+	err = ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods[:1], rtype, spec, tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.Templates) != 0 || len(fakePodControl.DeletePodName) != 0 {
+		t.Errorf("Expected no pods to be created or deleted before the deletions are observed, got %v created and %v deleted",
+			len(fakePodControl.Templates), fakePodControl.DeletePodName)
+	}
+	tfJobKey, err := KeyFunc(tfJob)
+	if err != nil {
+		t.Fatalf("Failed to get the key of the tfjob: %v", err)
+	}
+	for _, pod := range pods {
+		ctr.Expectations.DeletionObserved(expectation.GenExpectationPodsKey(tfJobKey, pod.Labels[commonv1.ReplicaTypeLabel]))
+	}
+
+	// The pods of the earlier attempt are deleted rather than counted.
+	fakePodControl.Clear()
+	err = ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods[:1], rtype, spec, tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.DeletePodName) != 1 || fakePodControl.DeletePodName[0] != pods[0].Name {
+		t.Errorf("Expected pod %s of the earlier attempt to be deleted, got %v", pods[0].Name, fakePodControl.DeletePodName)
+	}
+	if active := tfJob.Status.ReplicaStatuses[rtype].Active; active != 0 {
+		t.Errorf("Expected no active worker, got %d", active)
+	}
+
+	// The replicas are created again for the new attempt.
+	fakePodControl.Clear()
+	err = ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, nil, rtype, spec, tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Fatalf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.Templates) == 0 {
+		t.Fatalf("Expected the workers to be created again")
+	}
+	for _, template := range fakePodControl.Templates {
+		if template.Labels[tfRestartAttemptLabel] != "1" {
+			t.Errorf("Expected pod %s to be labeled with attempt 1, got %v", template.Name, template.Labels)
+		}
+	}
+}
+
+func TestJobRestartStatusUpdateFailure(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// The status of the tfjob fails to be updated without an api server.
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, kubeInformerFactory, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+
+	tfJob := testutil.NewTFJob(2, 0)
+	scope := tfv1.RestartScopeJob
+	tfJob.Spec.RestartScope = &scope
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].RestartPolicy = commonv1.RestartPolicyExitCode
+	unstructured, err := testutil.ConvertTFJobToUnstructured(tfJob)
+	if err != nil {
+		t.Fatalf("Failed to convert the TFJob to Unstructured: %v", err)
+	}
+	if err := ctr.tfJobInformer.GetIndexer().Add(unstructured); err != nil {
+		t.Fatalf("Failed to add tfjob to tfJobIndexer: %v", err)
+	}
+
+	podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+	running := testutil.NewPod(tfJob, testutil.LabelWorker, 1)
+	running.Status.Phase = v1.PodRunning
+	failed := testutil.NewPod(tfJob, testutil.LabelWorker, 0)
+	failed.Status.Phase = v1.PodFailed
+	failed.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name: tfv1.DefaultContainerName,
+		State: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{ExitCode: 130},
+		},
+	}}
+	for _, pod := range []*v1.Pod{running, failed} {
+		if err := podIndexer.Add(pod); err != nil {
+			t.Fatalf("Failed to add pod to podIndexer: %v", err)
+		}
+	}
+
+	if _, err := ctr.syncTFJob(testutil.GetKey(tfJob, t)); err == nil {
+		t.Fatalf("Expected the status update to fail")
+	}
+	if len(fakePodControl.DeletePodName) == 0 {
+		t.Fatalf("Expected the replicas to be restarted")
+	}
+
+	// The pods are reconciled again, although the attempt is not persisted.
+	if ctr.isRestartPending(tfJob) {
+		t.Errorf("Expected the restart attempt which is not persisted to be forgotten")
+	}
+}
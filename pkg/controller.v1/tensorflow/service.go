@@ -0,0 +1,100 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+// isHostnameClusterSpec returns if the replicas are addressed by their hostnames
+// in the Service of the tfjob in TF_CONFIG.
+func isHostnameClusterSpec(tfjob *tfv1.TFJob) bool {
+	return tfjob.Spec.ClusterSpecMode != nil && *tfjob.Spec.ClusterSpecMode == tfv1.ClusterSpecModeHostname
+}
+
+// genJobServiceName returns the name of the headless Service of the tfjob for
+// ClusterSpecModeHostname, which is the subdomain of its pods.
+func genJobServiceName(tfjob *tfv1.TFJob) string {
+	return tfjob.Name
+}
+
+// reconcileJobService creates the headless Service selecting all pods of the
+// tfjob for ClusterSpecModeHostname. The tfjob fails if a Service of the same
+// name is owned by another object.
+func (tc *TFController) reconcileJobService(tfjob *tfv1.TFJob) error {
+	if !isHostnameClusterSpec(tfjob) || isSucceeded(tfjob.Status.JobStatus) || isFailed(tfjob.Status.JobStatus) {
+		return nil
+	}
+
+	service, err := tc.ServiceLister.Services(tfjob.Namespace).Get(genJobServiceName(tfjob))
+	if err == nil {
+		if metav1.IsControlledBy(service, tfjob) {
+			return nil
+		}
+		// The hostnames of the replicas never resolve without the Service, thus
+		// the tfjob fails rather than waiting for the Service to be deleted.
+		msg := fmt.Sprintf("TFJob %s has failed because Service %s/%s already exists and is not owned by it.",
+			tfjob.Name, service.Namespace, service.Name)
+		tc.Recorder.Event(tfjob, v1.EventTypeWarning, tfJobServiceConflictReason, msg)
+		if err := commonutil.UpdateJobConditions(&tfjob.Status.JobStatus, commonv1.JobFailed, tfJobServiceConflictReason, msg); err != nil {
+			commonutil.LoggerForJob(tfjob).Infof("Append tfjob condition error: %v", err)
+			return err
+		}
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	commonutil.LoggerForJob(tfjob).Infof("Create Service %s for the replicas", genJobServiceName(tfjob))
+	labels := tc.GenLabels(tfjob.Name)
+	_, err = tc.KubeClientSet.CoreV1().Services(tfjob.Namespace).Create(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            genJobServiceName(tfjob),
+			Namespace:       tfjob.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*tc.GenOwnerReference(tfjob)},
+		},
+		Spec: v1.ServiceSpec{
+			// No port is needed for the DNS records of the pods.
+			ClusterIP: v1.ClusterIPNone,
+			Selector:  labels,
+			// The replicas need to resolve each other before they are ready.
+			PublishNotReadyAddresses: true,
+		},
+	})
+	// The Service is created by an earlier sync, but not observed yet.
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// setPodHostname sets the hostname of the pod to its name, and its subdomain
+// to the Service of the tfjob for ClusterSpecModeHostname.
+func setPodHostname(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec) {
+	if !isHostnameClusterSpec(tfjob) {
+		return
+	}
+	podTemplate.Spec.Hostname = podTemplate.Name
+	podTemplate.Spec.Subdomain = genJobServiceName(tfjob)
+}
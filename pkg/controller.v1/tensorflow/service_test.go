@@ -0,0 +1,128 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	"github.com/kubeflow/common/pkg/controller.v1/common"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestReconcileJobService(t *testing.T) {
+	kubeClientSet := kubefake.NewSimpleClientset()
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, kubeInformerFactory, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	serviceIndexer := kubeInformerFactory.Core().V1().Services().Informer().GetIndexer()
+
+	tfJob := testutil.NewTFJob(2, 1)
+	mode := tfv1.ClusterSpecModeHostname
+	tfJob.Spec.ClusterSpecMode = &mode
+
+	if err := ctr.reconcileJobService(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the Service: %v", err)
+	}
+	// The Service which is not observed yet is not created again.
+	if err := ctr.reconcileJobService(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the Service: %v", err)
+	}
+	service, err := kubeClientSet.CoreV1().Services(tfJob.Namespace).Get(tfJob.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the Service: %v", err)
+	}
+	if service.Spec.ClusterIP != v1.ClusterIPNone || !service.Spec.PublishNotReadyAddresses {
+		t.Errorf("Expected a headless Service publishing not ready addresses, got %v", service.Spec)
+	}
+	for key, value := range ctr.GenLabels(tfJob.Name) {
+		if service.Spec.Selector[key] != value {
+			t.Errorf("Expected the Service to select %s=%s, got %v", key, value, service.Spec.Selector)
+		}
+	}
+	if len(service.OwnerReferences) != 1 || service.OwnerReferences[0].Name != tfJob.Name {
+		t.Errorf("Expected the Service to be owned by the tfjob, got %v", service.OwnerReferences)
+	}
+
+	if err := serviceIndexer.Add(service); err != nil {
+		t.Fatalf("Failed to add the Service to the store: %v", err)
+	}
+	if err := ctr.reconcileJobService(tfJob); err != nil {
+		t.Fatalf("Failed to reconcile the Service: %v", err)
+	}
+	if len(tfJob.Status.Conditions) != 0 {
+		t.Errorf("Expected no condition, got %v", tfJob.Status.Conditions)
+	}
+
+	// The Service of another owner is not taken over, and the tfjob fails.
+	other := testutil.NewTFJob(1, 0)
+	other.UID = "other-uid"
+	other.Spec.ClusterSpecMode = &mode
+	if err := ctr.reconcileJobService(other); err != nil {
+		t.Fatalf("Failed to reconcile the Service: %v", err)
+	}
+	if !isFailed(other.Status.JobStatus) {
+		t.Errorf("Expected the tfjob to fail for the Service owned by another tfjob, got %v", other.Status.Conditions)
+	}
+}
+
+func TestHostnameClusterSpec(t *testing.T) {
+	tfJob := testutil.NewTFJobWithNamespace(2, 1, "ns0")
+	mode := tfv1.ClusterSpecModeHostname
+	tfJob.Spec.ClusterSpecMode = &mode
+
+	ctr := &TFController{}
+	podTemplate := tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Template.DeepCopy()
+	podTemplate.Name = common.GenGeneralName(tfJob.Name, testutil.LabelWorker, "1")
+	if err := ctr.SetClusterSpec(tfJob, podTemplate, testutil.LabelWorker, "1"); err != nil {
+		t.Fatalf("Failed to set cluster spec: %v", err)
+	}
+	setPodHostname(tfJob, podTemplate)
+
+	if podTemplate.Spec.Hostname != podTemplate.Name || podTemplate.Spec.Subdomain != tfJob.Name {
+		t.Errorf("Expected hostname %s and subdomain %s, got %s and %s",
+			podTemplate.Name, tfJob.Name, podTemplate.Spec.Hostname, podTemplate.Spec.Subdomain)
+	}
+	expected := `{"cluster":{"ps":["test-tfjob-ps-0.test-tfjob.ns0.svc:2222"],"worker":["test-tfjob-worker-0.test-tfjob.ns0.svc:2222",` +
+		`"test-tfjob-worker-1.test-tfjob.ns0.svc:2222"]},"task":{"type":"worker","index":1},"environment":"cloud"}`
+	if actual := podTemplate.Spec.Containers[0].Env[0].Value; actual != expected {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+// isInOrderStartup returns if the replicas of the tfjob are created in order.
+func isInOrderStartup(tfjob *tfv1.TFJob) bool {
+	return tfjob.Spec.StartupPolicy != nil && tfjob.Spec.StartupPolicy.Type == tfv1.StartupPolicyInOrder
+}
+
+// startupStage returns the stage in which the replicas of the type are created
+// with the InOrder startup policy. The replicas of a stage are created once all
+// replicas of the earlier stages have started.
+func startupStage(rtype commonv1.ReplicaType) int {
+	if tfv1.IsWorker(rtype) || tfv1.IsEvaluator(rtype) {
+		return 1
+	}
+	return 0
+}
+
+// waitingReplicaTypes returns the replica types of the earlier startup stages
+// which the replicas of rtype wait for, since not all of their pods have started.
+func waitingReplicaTypes(tfjob *tfv1.TFJob, pods []*v1.Pod, rtype commonv1.ReplicaType) []string {
+	if !isInOrderStartup(tfjob) {
+		return nil
+	}
+	waitForReady := tfjob.Spec.StartupPolicy.WaitForReady
+
+	var waiting []string
+	for t, spec := range tfjob.Spec.TFReplicaSpecs {
+		if startupStage(t) >= startupStage(rtype) || spec == nil || spec.Replicas == nil {
+			continue
+		}
+		rt := strings.ToLower(string(t))
+		started := 0
+		for _, pod := range pods {
+			if pod.Labels[tfReplicaTypeLabel] != rt || pod.DeletionTimestamp != nil {
+				continue
+			}
+			index, err := strconv.Atoi(pod.Labels[tfReplicaIndexLabel])
+			if err != nil || index < 0 || index >= int(*spec.Replicas) {
+				continue
+			}
+			if isPodStarted(pod, waitForReady) {
+				started++
+			}
+		}
+		if started < int(*spec.Replicas) {
+			waiting = append(waiting, string(t))
+		}
+	}
+	sort.Strings(waiting)
+	return waiting
+}
+
+// isPodStarted returns if the pod is running, and ready if waitForReady is set.
+// A succeeded pod has started as well.
+func isPodStarted(pod *v1.Pod, waitForReady bool) bool {
+	switch pod.Status.Phase {
+	case v1.PodSucceeded:
+		return true
+	case v1.PodRunning:
+		if !waitForReady {
+			return true
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodReady {
+				return condition.Status == v1.ConditionTrue
+			}
+		}
+	}
+	return false
+}
+
+// updateWaitingFor records in the status of the tfjob the replica types in
+// waiting, which the replicas of rtype wait for, and reports with the Waiting
+// condition and events when the tfjob starts and stops waiting. It is only
+// called for the replica types of the later startup stages, which wait for the
+// same replica types.
+func (tc *TFController) updateWaitingFor(tfjob *tfv1.TFJob, jobStatus *commonv1.JobStatus,
+	rtype commonv1.ReplicaType, waiting []string) {
+	var waitingFor []commonv1.ReplicaType
+	for _, t := range waiting {
+		waitingFor = append(waitingFor, commonv1.ReplicaType(t))
+	}
+	if reflect.DeepEqual(tfjob.Status.WaitingFor, waitingFor) {
+		return
+	}
+	tfjob.Status.WaitingFor = waitingFor
+	if len(waitingFor) == 0 {
+		msg := fmt.Sprintf("TFJob %s/%s has started the replicas it waited for.", tfjob.Namespace, tfjob.Name)
+		tc.Recorder.Event(tfjob, v1.EventTypeNormal, tfJobStartedReason, msg)
+		setProgressCondition(jobStatus, tfv1.JobWaiting, v1.ConditionFalse, tfJobStartedReason, msg)
+		return
+	}
+	readiness := "running"
+	if tfjob.Spec.StartupPolicy.WaitForReady {
+		readiness = "ready"
+	}
+	msg := fmt.Sprintf("TFJob %s/%s is waiting for %s replica(s) to be %s before creating %s replica(s).",
+		tfjob.Namespace, tfjob.Name, strings.Join(waiting, ", "), readiness, rtype)
+	if !hasCondition(*jobStatus, tfv1.JobWaiting) {
+		tc.Recorder.Event(tfjob, v1.EventTypeNormal, tfJobWaitingReason, msg)
+	}
+	setProgressCondition(jobStatus, tfv1.JobWaiting, v1.ConditionTrue, tfJobWaitingReason, msg)
+}
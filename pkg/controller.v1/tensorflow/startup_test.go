@@ -0,0 +1,179 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	"github.com/kubeflow/common/pkg/controller.v1/control"
+	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func TestWaitingReplicaTypes(t *testing.T) {
+	newPod := func(tfJob *tfv1.TFJob, typ string, index int, phase v1.PodPhase, ready bool) *v1.Pod {
+		pod := testutil.NewPod(tfJob, typ, index)
+		pod.Status.Phase = phase
+		status := v1.ConditionFalse
+		if ready {
+			status = v1.ConditionTrue
+		}
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+		return pod
+	}
+
+	testCases := map[string]struct {
+		policy   *tfv1.StartupPolicy
+		pods     func(*tfv1.TFJob) []*v1.Pod
+		expected []string
+	}{
+		"parallel": {
+			policy:   nil,
+			pods:     func(*tfv1.TFJob) []*v1.Pod { return nil },
+			expected: nil,
+		},
+		"no ps pod": {
+			policy:   &tfv1.StartupPolicy{Type: tfv1.StartupPolicyInOrder},
+			pods:     func(*tfv1.TFJob) []*v1.Pod { return nil },
+			expected: []string{"Chief", "PS"},
+		},
+		"ps pending": {
+			policy: &tfv1.StartupPolicy{Type: tfv1.StartupPolicyInOrder},
+			pods: func(j *tfv1.TFJob) []*v1.Pod {
+				return []*v1.Pod{
+					newPod(j, "chief", 0, v1.PodRunning, false),
+					newPod(j, testutil.LabelPS, 0, v1.PodRunning, false),
+					newPod(j, testutil.LabelPS, 1, v1.PodPending, false),
+				}
+			},
+			expected: []string{"PS"},
+		},
+		"all running": {
+			policy: &tfv1.StartupPolicy{Type: tfv1.StartupPolicyInOrder},
+			pods: func(j *tfv1.TFJob) []*v1.Pod {
+				return []*v1.Pod{
+					newPod(j, "chief", 0, v1.PodRunning, false),
+					newPod(j, testutil.LabelPS, 0, v1.PodRunning, false),
+					newPod(j, testutil.LabelPS, 1, v1.PodRunning, false),
+				}
+			},
+			expected: nil,
+		},
+		"wait for ready": {
+			policy: &tfv1.StartupPolicy{Type: tfv1.StartupPolicyInOrder, WaitForReady: true},
+			pods: func(j *tfv1.TFJob) []*v1.Pod {
+				return []*v1.Pod{
+					newPod(j, "chief", 0, v1.PodRunning, true),
+					newPod(j, testutil.LabelPS, 0, v1.PodRunning, true),
+					newPod(j, testutil.LabelPS, 1, v1.PodRunning, false),
+				}
+			},
+			expected: []string{"PS"},
+		},
+	}
+	for name, tc := range testCases {
+		tfJob := testutil.NewTFJobWithChief(2, 2)
+		tfJob.Spec.StartupPolicy = tc.policy
+		actual := waitingReplicaTypes(tfJob, tc.pods(tfJob), tfv1.TFReplicaTypeWorker)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, actual)
+		}
+		// The replicas of the first stage never wait.
+		if waiting := waitingReplicaTypes(tfJob, nil, tfv1.TFReplicaTypePS); len(waiting) != 0 {
+			t.Errorf("%s: expected PS not to wait, got %v", name, waiting)
+		}
+	}
+}
+
+func TestInOrderStartupDelaysPodCreation(t *testing.T) {
+	// Prepare the clientset and controller for the test.
+	kubeClientSet := kubeclientset.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &v1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	// Prepare the volcano clientset and controller for the test.
+	volcanoClientSet := volcanoclient.NewForConfigOrDie(&rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &batchv1beta1.SchemeGroupVersion,
+		},
+	},
+	)
+
+	config := &rest.Config{
+		Host: "",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion: &tfv1.SchemeGroupVersion,
+		},
+	}
+	tfJobClientSet := tfjobclientset.NewForConfigOrDie(config)
+	ctr, _, _ := newTFController(config, kubeClientSet,
+		volcanoClientSet, tfJobClientSet, 0, options.ServerOption{})
+	fakePodControl := &control.FakePodControl{}
+	ctr.PodControl = fakePodControl
+
+	tfJob := testutil.NewTFJob(2, 1)
+	tfJob.Spec.StartupPolicy = &tfv1.StartupPolicy{Type: tfv1.StartupPolicyInOrder}
+	rtype := tfv1.TFReplicaTypeWorker
+
+	// No worker is created while the PS is pending.
+	pods := testutil.NewPodList(1, v1.PodPending, tfJob, testutil.LabelPS, 0)
+	err := ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods, rtype,
+		tfJob.Spec.TFReplicaSpecs[rtype], tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Errorf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.Templates) != 0 {
+		t.Errorf("Expected no pod to be created, got %d", len(fakePodControl.Templates))
+	}
+	if waitingFor := tfJob.Status.WaitingFor; len(waitingFor) != 1 || waitingFor[0] != tfv1.TFReplicaTypePS {
+		t.Errorf("Expected the tfjob to be waiting for PS, got %v", waitingFor)
+	}
+	if !hasCondition(tfJob.Status.JobStatus, tfv1.JobWaiting) {
+		t.Errorf("Expected the tfjob to be waiting, got %v", tfJob.Status.Conditions)
+	}
+
+	// The workers are created once the PS is running.
+	pods[0].Status.Phase = v1.PodRunning
+	err = ctr.ReconcilePods(tfJob, &tfJob.Status.JobStatus, pods, rtype,
+		tfJob.Spec.TFReplicaSpecs[rtype], tfJob.Spec.TFReplicaSpecs)
+	if err != nil {
+		t.Errorf("Failed to reconcile pods: %v", err)
+	}
+	if len(fakePodControl.Templates) != 2 {
+		t.Errorf("Expected 2 pods to be created, got %d", len(fakePodControl.Templates))
+	}
+	if len(tfJob.Status.WaitingFor) != 0 {
+		t.Errorf("Expected the tfjob to stop waiting, got %v", tfJob.Status.WaitingFor)
+	}
+	conditions := tfJob.Status.Conditions
+	if len(conditions) != 1 || conditions[0].Type != tfv1.JobWaiting ||
+		conditions[0].Status != v1.ConditionFalse || conditions[0].Reason != tfJobStartedReason {
+		t.Errorf("Expected the tfjob to stop waiting, got %v", conditions)
+	}
+}
@@ -17,6 +17,9 @@ package tensorflow
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
@@ -41,6 +44,35 @@ const (
 	tfJobFailedReason = "TFJobFailed"
 	// tfJobRestarting is added in a tfjob when it is restarting.
 	tfJobRestartingReason = "TFJobRestarting"
+	// tfJobSuspendedReason is added in a tfjob when it is suspended.
+	tfJobSuspendedReason = "TFJobSuspended"
+	// tfJobResumedReason is added in a tfjob when it is resumed.
+	tfJobResumedReason = "TFJobResumed"
+	// tfJobWaitingReason is the reason of the event when a tfjob waits for replicas to start.
+	tfJobWaitingReason = "TFJobWaitingForReplicas"
+	// tfJobStartedReason is the reason of the event when the replicas a tfjob waited for have started.
+	tfJobStartedReason = "TFJobReplicasStarted"
+	// tfJobPendingTimeoutReason is added in a tfjob when a replica stays pending for too long.
+	tfJobPendingTimeoutReason = "TFJobPendingTimeout"
+	// tfJobFailuresToleratedReason is added in a running tfjob when failed replicas are tolerated.
+	tfJobFailuresToleratedReason = "TFJobFailuresTolerated"
+	// tfJobOOMKilledReason is added in a tfjob when a replica runs out of memory.
+	tfJobOOMKilledReason = "TFJobReplicaOOMKilled"
+	// tfJobDisruptedReason is added in a tfjob when a replica is killed by the infrastructure.
+	tfJobDisruptedReason = "TFJobReplicaDisrupted"
+	// tfJobPodGroupAdmittedReason is added in a tfjob when its PodGroup is admitted by the gang scheduler.
+	tfJobPodGroupAdmittedReason = "TFJobPodGroupAdmitted"
+	// tfJobPodGroupPendingReason is added in a tfjob when its PodGroup waits to be admitted.
+	tfJobPodGroupPendingReason = "TFJobPodGroupPending"
+	// tfJobServiceConflictReason is added in a tfjob when its Service is owned by another object.
+	tfJobServiceConflictReason = "TFJobServiceConflict"
+	// tfJobPDBConflictReason is added in a tfjob when its PodDisruptionBudget is owned by another object.
+	tfJobPDBConflictReason = "TFJobPodDisruptionBudgetConflict"
+	// tfJobMinMembersUnsupportedReason is added in a tfjob with MinMembers when
+	// the gang scheduler cannot require the minimum members of each replica type,
+	// and is the reason of the event when the Workers of an elastic tfjob are not
+	// required either.
+	tfJobMinMembersUnsupportedReason = "TFJobMinMembersUnsupported"
 )
 
 var (
@@ -74,11 +106,21 @@ func (tc *TFController) UpdateJobStatus(job interface{}, replicas map[commonv1.R
 
 	logger := commonutil.LoggerForJob(tfJob)
 
-	worker0Completed, err := tc.IsWorker0Completed(tfJob, replicas)
+	successType, jobSucceeded, err := tc.checkSuccessPolicy(tfJob, replicas, jobStatus)
 	if err != nil {
-		logger.Warnf("check if worker 0 completed error %v", err)
+		logger.Warnf("check success policy error %v", err)
 		return err
 	}
+	// The running condition tells the failed replicas which are tolerated, and
+	// is compared to report them once.
+	runningReason, runningMessage := "", ""
+	for _, condition := range jobStatus.Conditions {
+		if condition.Type == commonv1.JobRunning {
+			runningReason, runningMessage = condition.Reason, condition.Message
+		}
+	}
+	var toleratedFailures []string
+	jobRunning := false
 
 	// Set StartTime.
 	if jobStatus.StartTime == nil {
@@ -92,6 +134,7 @@ func (tc *TFController) UpdateJobStatus(job interface{}, replicas map[commonv1.R
 	}
 	// iterate the replica spec based on this order
 	allTypes := []commonv1.ReplicaType{
+		tfv1.TFReplicaTypeCoordinator,
 		tfv1.TFReplicaTypeChief,
 		tfv1.TFReplicaTypeEval,
 		tfv1.TFReplicaTypeMaster,
@@ -114,65 +157,31 @@ func (tc *TFController) UpdateJobStatus(job interface{}, replicas map[commonv1.R
 		logger.Infof("TFJob=%s/%s, ReplicaType=%s expected=%d, running=%d, failed=%d",
 			tfJob.Namespace, tfJob.Name, rtype, expected, running, failed)
 
-		// If the TFJob contains Chief or Master spec, then we will update the status
-		// according to the Chief/Master spec.
-		if ContainChieforMasterSpec(tfJob.Spec.TFReplicaSpecs) {
-			if tfv1.IsChieforMaster(rtype) {
-				if running > 0 {
-					msg := fmt.Sprintf("TFJob %s/%s is running.",
-						tfJob.Namespace, tfJob.Name)
-					err := commonutil.UpdateJobConditions(jobStatus,
-						commonv1.JobRunning, tfJobRunningReason, msg)
-					if err != nil {
-						commonutil.LoggerForJob(tfJob).Infof(
-							"Append tfjob condition error: %v", err)
-						return err
-					}
+		// The replica type deciding the success of the TFJob also decides
+		// whether it is running, see checkSuccessPolicy.
+		if rtype == successType {
+			if jobSucceeded {
+				msg := fmt.Sprintf("TFJob %s/%s successfully completed.",
+					tfJob.Namespace, tfJob.Name)
+				tc.Recorder.Event(tfJob, corev1.EventTypeNormal, tfJobSucceededReason, msg)
+				if jobStatus.CompletionTime == nil {
+					now := metav1.Now()
+					jobStatus.CompletionTime = &now
 				}
-				if expected == 0 {
-					msg := fmt.Sprintf("TFJob %s/%s successfully completed.",
-						tfJob.Namespace, tfJob.Name)
-					tc.Recorder.Event(tfJob, corev1.EventTypeNormal, tfJobSucceededReason, msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
-					}
-					err := commonutil.UpdateJobConditions(jobStatus,
-						commonv1.JobSucceeded, tfJobSucceededReason, msg)
-					if err != nil {
-						commonutil.LoggerForJob(tfJob).Infof("Append tfjob condition error: %v", err)
-						return err
-					}
-					tfJobsSuccessCount.WithLabelValues(tfJob.Namespace).Inc()
+				err := commonutil.UpdateJobConditions(jobStatus,
+					commonv1.JobSucceeded, tfJobSucceededReason, msg)
+				if err != nil {
+					commonutil.LoggerForJob(tfJob).Infof("Append tfjob condition error: %v", err)
+					return err
 				}
-			}
-		} else {
-			if rtype == tfv1.TFReplicaTypeWorker {
-				// Leave a succeeded condition for the following two cases:
-				// 1. If default success policy is used and worker 0 has completed.
-				// 2. If `SuccessPolicyAllWorkers` success policy is used and all workers are succeeded.
-				if expected == 0 || (worker0Completed && *tfJob.Spec.SuccessPolicy != tfv1.SuccessPolicyAllWorkers) {
-					msg := fmt.Sprintf("TFJob %s/%s successfully completed.",
-						tfJob.Namespace, tfJob.Name)
-					tc.Recorder.Event(tfJob, corev1.EventTypeNormal, tfJobSucceededReason, msg)
-					if jobStatus.CompletionTime == nil {
-						now := metav1.Now()
-						jobStatus.CompletionTime = &now
-					}
-					err := commonutil.UpdateJobConditions(jobStatus,
-						commonv1.JobSucceeded, tfJobSucceededReason, msg)
-					if err != nil {
-						commonutil.LoggerForJob(tfJob).Infof("Append tfjob condition error: %v", err)
-						return err
-					}
-					tfJobsSuccessCount.WithLabelValues(tfJob.Namespace).Inc()
-				} else if running > 0 {
-					// Some workers are still running, leave a running condition.
-					msg := fmt.Sprintf("TFJob %s/%s is running.",
-						tfJob.Namespace, tfJob.Name)
-					err := commonutil.UpdateJobConditions(jobStatus, commonv1.JobRunning, tfJobRunningReason, msg)
-					if err != nil {
-						commonutil.LoggerForJob(tfJob).Infof("Append tfjob condition error: %v", err)
+				tfJobsSuccessCount.WithLabelValues(tfJob.Namespace).Inc()
+			} else if running > 0 {
+				// Some replicas are still running, leave a running condition.
+				// Its reason tells the tolerated failed replicas once the
+				// failed replicas of all types are checked.
+				jobRunning = true
+				if runningReason != tfJobFailuresToleratedReason {
+					if err := tc.updateRunningCondition(tfJob, jobStatus, runningMessage, nil); err != nil {
 						return err
 					}
 				}
@@ -192,8 +201,34 @@ func (tc *TFController) UpdateJobStatus(job interface{}, replicas map[commonv1.R
 				// we know it because we update the status condition when reconciling the replicas
 				tfJobsFailureCount.WithLabelValues(tfJob.Namespace).Inc()
 			} else {
-				msg := fmt.Sprintf("TFJob %s/%s has failed because %d %s replica(s) failed.",
-					tfJob.Namespace, tfJob.Name, failed, rtype)
+				policy, tolerated := toleratesFailures(tfJob, rtype, failed)
+				if chiefOnly, err := tc.isToleratedByChiefOnly(tfJob, replicas, rtype, successType); err != nil {
+					return err
+				} else if chiefOnly {
+					policy, tolerated = fmt.Sprintf("success policy %s", tfv1.SuccessPolicyChiefOnly), true
+				} else {
+					policy = fmt.Sprintf("failure policy %s", policy)
+				}
+				msg := fmt.Sprintf("TFJob %s/%s has failed because %d %s replica(s) failed (%s).",
+					tfJob.Namespace, tfJob.Name, failed, rtype, policy)
+				if tolerated {
+					// The tolerated failures must not keep the tfjob from finishing.
+					reason, err := tc.checkSuccessUnreachable(tfJob, replicas, jobStatus, successType)
+					if err != nil {
+						return err
+					}
+					if reason != "" {
+						tolerated = false
+						msg = fmt.Sprintf("TFJob %s/%s has failed because %s, although %d failed %s replica(s) are tolerated by %s.",
+							tfJob.Namespace, tfJob.Name, reason, failed, rtype, policy)
+					}
+				}
+				if tolerated {
+					logger.Infof("TFJob=%s/%s, ReplicaType=%s tolerates %d failed replica(s) by %s",
+						tfJob.Namespace, tfJob.Name, rtype, failed, policy)
+					toleratedFailures = append(toleratedFailures, fmt.Sprintf("%d %s replica(s) by %s", failed, rtype, policy))
+					continue
+				}
 				tc.Recorder.Event(tfJob, corev1.EventTypeNormal, tfJobFailedReason, msg)
 				if jobStatus.CompletionTime == nil {
 					now := metav1.Now()
@@ -209,11 +244,16 @@ func (tc *TFController) UpdateJobStatus(job interface{}, replicas map[commonv1.R
 			}
 		}
 	}
+	if jobRunning {
+		if err := tc.updateRunningCondition(tfJob, jobStatus, runningMessage, toleratedFailures); err != nil {
+			return err
+		}
+	}
 	// we assign the jobStatus to the tfJob.Status for testing purpose
 	// it won't effect the main reconcile logic
 	// because we already use oldStatus := jobStatus.DeepCopy() to record the oldStatus
 	// and use !reflect.DeepEqual(*oldStatus, jobStatus) to decide whether to update the tfJob or not
-	tfJob.Status = *jobStatus.DeepCopy()
+	tfJob.Status.JobStatus = *jobStatus.DeepCopy()
 
 	return nil
 }
@@ -232,11 +272,292 @@ func (tc *TFController) UpdateJobStatusInApiServer(job interface{}, jobStatus *c
 			tfJob.Name, time.Since(startTime))
 	}()
 
-	tfJob = tfJob.DeepCopy()
-	tfJob.Status = *jobStatus.DeepCopy()
+	tfJobCopy := tfJob.DeepCopy()
+	tfJobCopy.Status.JobStatus = *jobStatus.DeepCopy()
+
+	updated, err := tc.tfJobClientSet.KubeflowV1().TFJobs(tfJob.Namespace).UpdateStatus(tfJobCopy)
+	if err != nil {
+		return err
+	}
+	// Keep the resource version up to date, so that the status can be
+	// updated again in the same sync, see updateTFJobOnlyStatus.
+	tfJob.ResourceVersion = updated.ResourceVersion
+	return nil
+}
+
+// checkSuccessPolicy returns the replica type whose status decides the success
+// of the TFJob, and whether the TFJob has succeeded according to its success policy.
+//
+// The chief decides unless the policy is based on the number of succeeded workers.
+// Worker 0 is the chief if there is no Chief or Master replica. With the AllWorkers
+// policy, the failed workers tolerated by the failure policy of the workers do not
+// need to succeed.
+func (tc *TFController) checkSuccessPolicy(tfJob *tfv1.TFJob, replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec,
+	jobStatus *commonv1.JobStatus) (commonv1.ReplicaType, bool, error) {
+	policy := tfv1.SuccessPolicyDefault
+	if tfJob.Spec.SuccessPolicy != nil {
+		policy = *tfJob.Spec.SuccessPolicy
+	}
+
+	// The coordinator decides the success of the TFJob regardless of the policy.
+	if spec, ok := replicas[tfv1.TFReplicaTypeCoordinator]; ok {
+		status := jobStatus.ReplicaStatuses[tfv1.TFReplicaTypeCoordinator]
+		return tfv1.TFReplicaTypeCoordinator, status != nil && status.Succeeded >= *spec.Replicas, nil
+	}
+
+	workerSucceeded, workerFailed := int32(0), int32(0)
+	if status := jobStatus.ReplicaStatuses[tfv1.TFReplicaTypeWorker]; status != nil {
+		workerSucceeded, workerFailed = status.Succeeded, status.Failed
+	}
+	switch policy {
+	case tfv1.SuccessPolicyAnyWorker:
+		return tfv1.TFReplicaTypeWorker, workerSucceeded > 0, nil
+	case tfv1.SuccessPolicyWorkerThreshold:
+		threshold, err := successThreshold(tfJob, replicas)
+		if err != nil {
+			return "", false, err
+		}
+		return tfv1.TFReplicaTypeWorker, workerSucceeded >= threshold, nil
+	}
+
+	for rtype, spec := range replicas {
+		if tfv1.IsChieforMaster(rtype) {
+			status := jobStatus.ReplicaStatuses[rtype]
+			return rtype, status != nil && status.Succeeded >= *spec.Replicas, nil
+		}
+	}
+
+	spec, ok := replicas[tfv1.TFReplicaTypeWorker]
+	if !ok {
+		return "", false, nil
+	}
+	if workerSucceeded >= *spec.Replicas {
+		return tfv1.TFReplicaTypeWorker, true, nil
+	}
+	if policy == tfv1.SuccessPolicyAllWorkers {
+		tolerated := int32(0)
+		if _, ok := toleratesFailures(tfJob, tfv1.TFReplicaTypeWorker, workerFailed); ok {
+			tolerated = workerFailed
+		}
+		return tfv1.TFReplicaTypeWorker, workerSucceeded > 0 && workerSucceeded+tolerated >= *spec.Replicas, nil
+	}
+	worker0Completed, err := tc.IsWorker0Completed(tfJob, replicas)
+	if err != nil {
+		return "", false, err
+	}
+	return tfv1.TFReplicaTypeWorker, worker0Completed, nil
+}
+
+// updateRunningCondition sets the running condition of the tfjob. Its reason
+// and message tell the failed replicas which are tolerated by the failure
+// policies or the success policy, which are also reported with an event.
+func (tc *TFController) updateRunningCondition(tfJob *tfv1.TFJob, jobStatus *commonv1.JobStatus,
+	runningMessage string, toleratedFailures []string) error {
+	reason := tfJobRunningReason
+	msg := fmt.Sprintf("TFJob %s/%s is running.", tfJob.Namespace, tfJob.Name)
+	if len(toleratedFailures) > 0 {
+		reason = tfJobFailuresToleratedReason
+		msg = fmt.Sprintf("TFJob %s/%s is running and tolerates %s.",
+			tfJob.Namespace, tfJob.Name, strings.Join(toleratedFailures, ", "))
+		if msg != runningMessage {
+			tc.Recorder.Event(tfJob, corev1.EventTypeWarning, tfJobFailuresToleratedReason, msg)
+		}
+	}
+	if err := commonutil.UpdateJobConditions(jobStatus, commonv1.JobRunning, reason, msg); err != nil {
+		commonutil.LoggerForJob(tfJob).Infof("Append tfjob condition error: %v", err)
+		return err
+	}
+	// The message is not updated if the reason does not change, e.g. when more
+	// failed replicas are tolerated.
+	for i := range jobStatus.Conditions {
+		if c := &jobStatus.Conditions[i]; c.Type == commonv1.JobRunning && c.Status == v1.ConditionTrue && c.Message != msg {
+			c.Message = msg
+			c.LastUpdateTime = metav1.Now()
+		}
+	}
+	return nil
+}
+
+// checkSuccessUnreachable returns why the tfjob can no longer succeed according
+// to its success policy, or "" if it still can. It tells whether failures, even
+// if they are tolerated, have removed the replicas which need to succeed, e.g.
+// the chief, so that the tfjob fails instead of running forever.
+func (tc *TFController) checkSuccessUnreachable(tfJob *tfv1.TFJob, replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec,
+	jobStatus *commonv1.JobStatus, successType commonv1.ReplicaType) (string, error) {
+	spec, ok := replicas[successType]
+	if !ok || spec.Replicas == nil {
+		return "", nil
+	}
+	succeeded, failed := int32(0), int32(0)
+	if status := jobStatus.ReplicaStatuses[successType]; status != nil {
+		succeeded, failed = status.Succeeded, status.Failed
+	}
+	if successType != tfv1.TFReplicaTypeWorker {
+		if failed > 0 {
+			return fmt.Sprintf("the %s replica, which decides its success, failed", successType), nil
+		}
+		return "", nil
+	}
+
+	policy := tfv1.SuccessPolicyDefault
+	if tfJob.Spec.SuccessPolicy != nil {
+		policy = *tfJob.Spec.SuccessPolicy
+	}
+	switch policy {
+	case tfv1.SuccessPolicyAnyWorker, tfv1.SuccessPolicyAllWorkers:
+		if succeeded == 0 && failed >= *spec.Replicas {
+			return "all Worker replicas failed", nil
+		}
+	case tfv1.SuccessPolicyWorkerThreshold:
+		threshold, err := successThreshold(tfJob, replicas)
+		if err != nil {
+			return "", err
+		}
+		if remaining := *spec.Replicas - failed; remaining < threshold {
+			return fmt.Sprintf("only %d Worker replica(s) can succeed, fewer than the success threshold %d",
+				remaining, threshold), nil
+		}
+	default:
+		worker0Failed, err := tc.isWorker0Failed(tfJob, replicas)
+		if err != nil {
+			return "", err
+		}
+		if worker0Failed {
+			return "Worker 0, which decides its success, failed", nil
+		}
+	}
+	return "", nil
+}
+
+// isToleratedByChiefOnly returns whether the failed replicas of the type are
+// tolerated by the ChiefOnly success policy, by which only the chief, i.e. the
+// replica type deciding the success of the tfjob, decides its outcome. Worker 0
+// is the chief if there is no Chief or Master replica, thus only the failure of
+// worker 0 fails the tfjob then.
+func (tc *TFController) isToleratedByChiefOnly(tfJob *tfv1.TFJob, replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec,
+	rtype, successType commonv1.ReplicaType) (bool, error) {
+	if tfJob.Spec.SuccessPolicy == nil || *tfJob.Spec.SuccessPolicy != tfv1.SuccessPolicyChiefOnly {
+		return false, nil
+	}
+	if rtype != successType {
+		return true, nil
+	}
+	if rtype != tfv1.TFReplicaTypeWorker {
+		return false, nil
+	}
+	worker0Failed, err := tc.isWorker0Failed(tfJob, replicas)
+	if err != nil {
+		return false, err
+	}
+	return !worker0Failed, nil
+}
+
+// updateTFJobOnlyStatus updates the status of the tfjob in the api server if it
+// has not been updated by ReconcileJobs. ReconcileJobs only updates it when the
+// common job status changes during the reconciliation, so the status fields which
+// are not part of the common job status, e.g. the replica index statuses and the
+// status of the PodGroup, are not updated on their own.
+func (tc *TFController) updateTFJobOnlyStatus(oldTFJob, tfJob *tfv1.TFJob) error {
+	if tfJob.ResourceVersion != oldTFJob.ResourceVersion {
+		// The status has been updated together with the common job status.
+		return nil
+	}
+	if reflect.DeepEqual(oldTFJob.Status, tfJob.Status) {
+		return nil
+	}
+	return tc.UpdateJobStatusInApiServer(tfJob, &tfJob.Status.JobStatus)
+}
+
+// getReplicaIndexStatus returns the status of the replica index, or nil if it is not recorded.
+func getReplicaIndexStatus(jobStatus *tfv1.TFJobStatus, rtype commonv1.ReplicaType, index int) *tfv1.ReplicaIndexStatus {
+	statuses := jobStatus.ReplicaIndexStatuses[rtype]
+	for i := range statuses {
+		if statuses[i].Index == int32(index) {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// getOrAddReplicaIndexStatus returns the status of the replica index, which is
+// added in the order of the indexes if it is not recorded yet.
+func getOrAddReplicaIndexStatus(jobStatus *tfv1.TFJobStatus, rtype commonv1.ReplicaType, index int) *tfv1.ReplicaIndexStatus {
+	if status := getReplicaIndexStatus(jobStatus, rtype, index); status != nil {
+		return status
+	}
+	if jobStatus.ReplicaIndexStatuses == nil {
+		jobStatus.ReplicaIndexStatuses = map[commonv1.ReplicaType][]tfv1.ReplicaIndexStatus{}
+	}
+	statuses := append(jobStatus.ReplicaIndexStatuses[rtype], tfv1.ReplicaIndexStatus{Index: int32(index)})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Index < statuses[j].Index })
+	jobStatus.ReplicaIndexStatuses[rtype] = statuses
+	return getReplicaIndexStatus(jobStatus, rtype, index)
+}
 
-	_, err := tc.tfJobClientSet.KubeflowV1().TFJobs(tfJob.Namespace).UpdateStatus(tfJob)
-	return err
+// pruneReplicaIndexStatuses removes the statuses of the replica indexes out of
+// the range of the replicas, e.g. after the replicas are scaled down.
+func pruneReplicaIndexStatuses(jobStatus *tfv1.TFJobStatus, rtype commonv1.ReplicaType, replicas int) {
+	statuses, ok := jobStatus.ReplicaIndexStatuses[rtype]
+	if !ok {
+		return
+	}
+	var pruned []tfv1.ReplicaIndexStatus
+	for _, status := range statuses {
+		if status.Index < int32(replicas) {
+			pruned = append(pruned, status)
+		}
+	}
+	if len(pruned) == 0 {
+		delete(jobStatus.ReplicaIndexStatuses, rtype)
+		return
+	}
+	jobStatus.ReplicaIndexStatuses[rtype] = pruned
+}
+
+// recordReplicaRestart records a restart of the replica index in the status of the tfjob.
+func recordReplicaRestart(jobStatus *tfv1.TFJobStatus, rtype commonv1.ReplicaType, index int) {
+	now := metav1.Now()
+	status := getOrAddReplicaIndexStatus(jobStatus, rtype, index)
+	status.Restarts++
+	status.LastRestartTime = &now
+}
+
+// recordReplicaPod records the state of the current pod of the replica index in
+// the status of the tfjob, so that it tells why the replica is not running.
+func recordReplicaPod(jobStatus *tfv1.TFJobStatus, rtype commonv1.ReplicaType, index int,
+	pod *corev1.Pod, containerName string) {
+	status := getOrAddReplicaIndexStatus(jobStatus, rtype, index)
+	status.PodName = pod.Name
+	status.Phase = pod.Status.Phase
+	status.NodeName = pod.Spec.NodeName
+	status.ContainerRestarts = 0
+	status.LastExitCode = nil
+	status.TerminationReason = ""
+	status.WaitingReason = ""
+
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Name != containerName {
+			continue
+		}
+		status.ContainerRestarts = container.RestartCount
+		terminated := container.State.Terminated
+		if terminated == nil {
+			terminated = container.LastTerminationState.Terminated
+		}
+		if terminated != nil {
+			exitCode := terminated.ExitCode
+			status.LastExitCode = &exitCode
+			status.TerminationReason = terminated.Reason
+		}
+		if waiting := container.State.Waiting; waiting != nil {
+			status.WaitingReason = waiting.Reason
+		}
+	}
+	if status.WaitingReason == "" && pod.Status.Phase == corev1.PodPending {
+		if reason, _ := podPendingReason(pod); reason != string(corev1.PodPending) {
+			status.WaitingReason = reason
+		}
+	}
 }
 
 // initializeReplicaStatuses initializes the ReplicaStatuses for replica.
@@ -276,3 +597,46 @@ func hasCondition(status commonv1.JobStatus, condType commonv1.JobConditionType)
 	}
 	return false
 }
+
+// setProgressCondition sets a condition which reports the progress of the
+// tfjob besides its phase, e.g. Waiting. Unlike UpdateJobConditions, it
+// updates the condition in place and adds it before the last condition, which
+// is the phase of the tfjob, e.g. Running, shown by kubectl. It returns true if
+// the conditions are changed.
+func setProgressCondition(jobStatus *commonv1.JobStatus, condType commonv1.JobConditionType,
+	status v1.ConditionStatus, reason, message string) bool {
+	now := metav1.Now()
+	for i := range jobStatus.Conditions {
+		c := &jobStatus.Conditions[i]
+		if c.Type != condType {
+			continue
+		}
+		if c.Status == status && c.Reason == reason && c.Message == message {
+			return false
+		}
+		if c.Status != status {
+			c.LastTransitionTime = now
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		c.LastUpdateTime = now
+		return true
+	}
+	condition := commonv1.JobCondition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+	}
+	last := len(jobStatus.Conditions) - 1
+	if last < 0 {
+		jobStatus.Conditions = append(jobStatus.Conditions, condition)
+		return true
+	}
+	conditions := append([]commonv1.JobCondition{}, jobStatus.Conditions[:last]...)
+	jobStatus.Conditions = append(append(conditions, condition), jobStatus.Conditions[last])
+	return true
+}
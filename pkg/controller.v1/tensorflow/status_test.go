@@ -21,16 +21,20 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	kubeclientset "k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 
 	batchv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	"github.com/kubeflow/common/pkg/controller.v1/control"
+	commonutil "github.com/kubeflow/common/pkg/util"
 	"github.com/kubeflow/tf-operator/cmd/tf-operator.v1/app/options"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 	tfjobclientset "github.com/kubeflow/tf-operator/pkg/client/clientset/versioned"
@@ -68,18 +72,20 @@ func TestFailed(t *testing.T) {
 	ctr.tfJobInformerSynced = testutil.AlwaysReady
 	ctr.PodInformerSynced = testutil.AlwaysReady
 	ctr.ServiceInformerSynced = testutil.AlwaysReady
+	ctr.configMapInformerSynced = testutil.AlwaysReady
+	ctr.pdbInformerSynced = testutil.AlwaysReady
 
 	tfJob := testutil.NewTFJob(3, 0)
-	initializeReplicaStatuses(&tfJob.Status, tfv1.TFReplicaTypeWorker)
+	initializeReplicaStatuses(&tfJob.Status.JobStatus, tfv1.TFReplicaTypeWorker)
 	pod := testutil.NewBasePod("pod", tfJob)
 	pod.Status.Phase = v1.PodFailed
 
-	updateJobReplicaStatuses(&tfJob.Status, tfv1.TFReplicaTypeWorker, pod)
+	updateJobReplicaStatuses(&tfJob.Status.JobStatus, tfv1.TFReplicaTypeWorker, pod)
 	if tfJob.Status.ReplicaStatuses[commonv1.ReplicaType(tfv1.TFReplicaTypeWorker)].Failed != 1 {
 		t.Errorf("Failed to set the failed to 1")
 	}
 
-	err := ctr.UpdateJobStatus(tfJob, tfJob.Spec.TFReplicaSpecs, &tfJob.Status)
+	err := ctr.UpdateJobStatus(tfJob, tfJob.Spec.TFReplicaSpecs, &tfJob.Status.JobStatus)
 	if err != nil {
 		t.Errorf("Expected error %v to be nil", err)
 	}
@@ -326,6 +332,198 @@ func TestStatus(t *testing.T) {
 			worker0Completed:        true,
 			expectedType:            commonv1.JobFailed,
 		},
+		testCase{
+			description:             "(No chief worker, successPolicy: ChiefOnly) worker-0 is succeeded, 3 workers are active",
+			tfJob:                   testutil.NewTFJobWithSuccessPolicy(4, 0, tfv1.SuccessPolicyChiefOnly),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 1,
+			expectedActiveWorker:    3,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        true,
+			expectedType:            commonv1.JobSucceeded,
+		},
+		testCase{
+			description:             "(No chief worker, successPolicy: AnyWorker) worker-1 is succeeded, 3 workers are active",
+			tfJob:                   testutil.NewTFJobWithSuccessPolicy(4, 0, tfv1.SuccessPolicyAnyWorker),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 1,
+			expectedActiveWorker:    3,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobSucceeded,
+		},
+		testCase{
+			description:             "(No chief worker, successPolicy: AnyWorker) 4 workers are active",
+			tfJob:                   testutil.NewTFJobWithSuccessPolicy(4, 0, tfv1.SuccessPolicyAnyWorker),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 0,
+			expectedActiveWorker:    4,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobRunning,
+		},
+		testCase{
+			description:             "(No chief worker, successPolicy: WorkerThreshold 3) 2 workers are succeeded, 2 workers are active",
+			tfJob:                   testutil.NewTFJobWithSuccessThreshold(4, 0, intstr.FromInt(3)),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 2,
+			expectedActiveWorker:    2,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        true,
+			expectedType:            commonv1.JobRunning,
+		},
+		testCase{
+			description:             "(No chief worker, successPolicy: WorkerThreshold 3) 3 workers are succeeded, 1 worker is active",
+			tfJob:                   testutil.NewTFJobWithSuccessThreshold(4, 0, intstr.FromInt(3)),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 3,
+			expectedActiveWorker:    1,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        true,
+			expectedType:            commonv1.JobSucceeded,
+		},
+		testCase{
+			description:             "(No chief worker, successPolicy: WorkerThreshold 50%) 2 workers are succeeded, 2 workers are active",
+			tfJob:                   testutil.NewTFJobWithSuccessThreshold(4, 0, intstr.FromString("50%")),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 2,
+			expectedActiveWorker:    2,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobSucceeded,
+		},
+		testCase{
+			description:             "(failurePolicy: Worker Tolerate 1) worker-0 is failed, 3 workers are active",
+			tfJob:                   testutil.NewTFJobWithFailurePolicy(4, 0, tfv1.TFReplicaTypeWorker, tfv1.FailurePolicy{Type: tfv1.FailurePolicyTolerate, MaxFailures: tfv1.Int32(1)}),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    1,
+			expectedSucceededWorker: 0,
+			expectedActiveWorker:    3,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobFailed,
+		},
+		testCase{
+			description:             "(failurePolicy: Worker Tolerate 1) 2 workers are failed, 2 workers are active",
+			tfJob:                   testutil.NewTFJobWithFailurePolicy(4, 0, tfv1.TFReplicaTypeWorker, tfv1.FailurePolicy{Type: tfv1.FailurePolicyTolerate, MaxFailures: tfv1.Int32(1)}),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        0,
+			expectedFailedWorker:    2,
+			expectedSucceededWorker: 0,
+			expectedActiveWorker:    2,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobFailed,
+		},
+		testCase{
+			description:             "(failurePolicy: PS Ignore) a PS is failed, 4 workers are active",
+			tfJob:                   testutil.NewTFJobWithFailurePolicy(4, 2, tfv1.TFReplicaTypePS, tfv1.FailurePolicy{Type: tfv1.FailurePolicyIgnore}),
+			expectedFailedPS:        1,
+			expectedSucceededPS:     0,
+			expectedActivePS:        1,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 0,
+			expectedActiveWorker:    4,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobRunning,
+		},
+		testCase{
+			description:             "Coordinator is succeeded, workers and PS are running",
+			tfJob:                   testutil.NewTFJobWithCoordinator(2, 1),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        1,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 0,
+			expectedActiveWorker:    2,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  1,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobSucceeded,
+		},
+		testCase{
+			description:             "Coordinator is running, workers are succeeded",
+			tfJob:                   testutil.NewTFJobWithCoordinator(2, 1),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        1,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 2,
+			expectedActiveWorker:    0,
+			expectedFailedChief:     0,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     1,
+			restart:                 false,
+			worker0Completed:        true,
+			expectedType:            commonv1.JobRunning,
+		},
+		testCase{
+			description:             "Coordinator is failed",
+			tfJob:                   testutil.NewTFJobWithCoordinator(2, 1),
+			expectedFailedPS:        0,
+			expectedSucceededPS:     0,
+			expectedActivePS:        1,
+			expectedFailedWorker:    0,
+			expectedSucceededWorker: 0,
+			expectedActiveWorker:    2,
+			expectedFailedChief:     1,
+			expectedSucceededChief:  0,
+			expectedActiveChief:     0,
+			restart:                 false,
+			worker0Completed:        false,
+			expectedType:            commonv1.JobFailed,
+		},
 		testCase{
 			description:             "Chief is running, workers are failed",
 			tfJob:                   testutil.NewTFJobWithChief(4, 2),
@@ -458,6 +656,8 @@ func TestStatus(t *testing.T) {
 		ctr.tfJobInformerSynced = testutil.AlwaysReady
 		ctr.PodInformerSynced = testutil.AlwaysReady
 		ctr.ServiceInformerSynced = testutil.AlwaysReady
+		ctr.configMapInformerSynced = testutil.AlwaysReady
+		ctr.pdbInformerSynced = testutil.AlwaysReady
 		tfJobIndexer := ctr.tfJobInformer.GetIndexer()
 		podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
 
@@ -478,22 +678,28 @@ func TestStatus(t *testing.T) {
 			t.Errorf("Failed to add tfjob to tfJobIndexer: %v", err)
 		}
 
-		initializeReplicaStatuses(&c.tfJob.Status, tfv1.TFReplicaTypeWorker)
-		initializeReplicaStatuses(&c.tfJob.Status, tfv1.TFReplicaTypeChief)
-		initializeReplicaStatuses(&c.tfJob.Status, tfv1.TFReplicaTypePS)
+		// The chief columns of the test case apply to the coordinator if there is one.
+		chiefType := tfv1.TFReplicaTypeChief
+		if _, ok := c.tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeCoordinator]; ok {
+			chiefType = tfv1.TFReplicaTypeCoordinator
+		}
+
+		initializeReplicaStatuses(&c.tfJob.Status.JobStatus, tfv1.TFReplicaTypeWorker)
+		initializeReplicaStatuses(&c.tfJob.Status.JobStatus, chiefType)
+		initializeReplicaStatuses(&c.tfJob.Status.JobStatus, tfv1.TFReplicaTypePS)
 
 		setStatusForTest(c.tfJob, tfv1.TFReplicaTypePS, c.expectedFailedPS, c.expectedSucceededPS, c.expectedActivePS, c.restart, c.worker0Completed, podIndexer, t)
 		setStatusForTest(c.tfJob, tfv1.TFReplicaTypeWorker, c.expectedFailedWorker, c.expectedSucceededWorker, c.expectedActiveWorker, c.restart, c.worker0Completed, podIndexer, t)
-		setStatusForTest(c.tfJob, tfv1.TFReplicaTypeChief, c.expectedFailedChief, c.expectedSucceededChief, c.expectedActiveChief, c.restart, c.worker0Completed, podIndexer, t)
+		setStatusForTest(c.tfJob, chiefType, c.expectedFailedChief, c.expectedSucceededChief, c.expectedActiveChief, c.restart, c.worker0Completed, podIndexer, t)
 
-		// err = ctr.UpdateJobStatus(c.tfJob, c.tfJob.Spec.TFReplicaSpecs, &c.tfJob.Status)
+		// err = ctr.UpdateJobStatus(c.tfJob, c.tfJob.Spec.TFReplicaSpecs, &c.tfJob.Status.JobStatus)
 		// if err != nil {
 		// 	t.Errorf("%s: Expected error %v to be nil", c.description, err)
 		// }
-		_ = ctr.ReconcileJobs(c.tfJob, c.tfJob.Spec.TFReplicaSpecs, c.tfJob.Status, &c.tfJob.Spec.RunPolicy)
+		_ = ctr.ReconcileJobs(c.tfJob, c.tfJob.Spec.TFReplicaSpecs, c.tfJob.Status.JobStatus, &c.tfJob.Spec.RunPolicy)
 
 		// Test filterOutCondition
-		filterOutConditionTest(c.tfJob.Status, t)
+		filterOutConditionTest(c.tfJob.Status.JobStatus, t)
 
 		found := false
 		for _, condition := range c.tfJob.Status.Conditions {
@@ -507,6 +713,143 @@ func TestStatus(t *testing.T) {
 	}
 }
 
+func TestSuccessPolicies(t *testing.T) {
+	testCases := []struct {
+		description      string
+		tfJob            *tfv1.TFJob
+		failedWorker     int32
+		succeededWorker  int32
+		activeWorker     int32
+		activeChief      int32
+		worker0Completed bool
+		expectedType     commonv1.JobConditionType
+		expectedReason   string
+	}{
+		{
+			description:  "(successPolicy: Default) chief is running, 1 worker is failed",
+			tfJob:        testutil.NewTFJobWithChief(2, 0),
+			failedWorker: 1,
+			activeWorker: 1,
+			activeChief:  1,
+			expectedType: commonv1.JobFailed,
+		},
+		{
+			description:  "(successPolicy: ChiefOnly) chief is running, 1 worker is failed",
+			tfJob:        withSuccessPolicy(testutil.NewTFJobWithChief(2, 0), tfv1.SuccessPolicyChiefOnly),
+			failedWorker: 1,
+			activeWorker: 1,
+			activeChief:  1,
+			expectedType: commonv1.JobRunning,
+		},
+		{
+			description:  "(No chief worker, successPolicy: ChiefOnly) worker-0 is failed",
+			tfJob:        testutil.NewTFJobWithSuccessPolicy(2, 0, tfv1.SuccessPolicyChiefOnly),
+			failedWorker: 1,
+			activeWorker: 1,
+			expectedType: commonv1.JobFailed,
+		},
+		{
+			description:      "(No chief worker, successPolicy: AllWorkers) 3 workers are succeeded, 1 worker is failed",
+			tfJob:            testutil.NewTFJobWithSuccessPolicy(4, 0, tfv1.SuccessPolicyAllWorkers),
+			failedWorker:     1,
+			succeededWorker:  3,
+			worker0Completed: true,
+			expectedType:     commonv1.JobFailed,
+		},
+		{
+			description: "(No chief worker, successPolicy: AllWorkers, failurePolicy: Worker Tolerate 1) " +
+				"3 workers are succeeded, 1 worker is failed",
+			tfJob: withSuccessPolicy(testutil.NewTFJobWithFailurePolicy(4, 0, tfv1.TFReplicaTypeWorker,
+				tfv1.FailurePolicy{Type: tfv1.FailurePolicyTolerate, MaxFailures: tfv1.Int32(1)}), tfv1.SuccessPolicyAllWorkers),
+			failedWorker:     1,
+			succeededWorker:  3,
+			worker0Completed: true,
+			expectedType:     commonv1.JobSucceeded,
+		},
+		{
+			description: "(failurePolicy: Worker Tolerate 1) chief is running, 1 worker is failed",
+			tfJob: withChief(testutil.NewTFJobWithFailurePolicy(2, 0, tfv1.TFReplicaTypeWorker,
+				tfv1.FailurePolicy{Type: tfv1.FailurePolicyTolerate, MaxFailures: tfv1.Int32(1)})),
+			failedWorker:   1,
+			activeWorker:   1,
+			activeChief:    1,
+			expectedType:   commonv1.JobRunning,
+			expectedReason: tfJobFailuresToleratedReason,
+		},
+		{
+			description: "(No chief worker, successPolicy: AllWorkers, failurePolicy: Worker Ignore) 2 workers are failed",
+			tfJob: withSuccessPolicy(testutil.NewTFJobWithFailurePolicy(2, 0, tfv1.TFReplicaTypeWorker,
+				tfv1.FailurePolicy{Type: tfv1.FailurePolicyIgnore}), tfv1.SuccessPolicyAllWorkers),
+			failedWorker: 2,
+			expectedType: commonv1.JobFailed,
+		},
+		{
+			description: "(No chief worker, successPolicy: AnyWorker, failurePolicy: Worker Ignore) 1 worker is failed, 1 worker is active",
+			tfJob: withSuccessPolicy(testutil.NewTFJobWithFailurePolicy(2, 0, tfv1.TFReplicaTypeWorker,
+				tfv1.FailurePolicy{Type: tfv1.FailurePolicyIgnore}), tfv1.SuccessPolicyAnyWorker),
+			failedWorker:   1,
+			activeWorker:   1,
+			expectedType:   commonv1.JobRunning,
+			expectedReason: tfJobFailuresToleratedReason,
+		},
+		{
+			description: "(No chief worker, successPolicy: WorkerThreshold 3, failurePolicy: Worker Tolerate 2) " +
+				"1 worker is succeeded, 2 workers are failed, 1 worker is active",
+			tfJob: withFailurePolicy(testutil.NewTFJobWithSuccessThreshold(4, 0, intstr.FromInt(3)), tfv1.TFReplicaTypeWorker,
+				tfv1.FailurePolicy{Type: tfv1.FailurePolicyTolerate, MaxFailures: tfv1.Int32(2)}),
+			succeededWorker: 1,
+			failedWorker:    2,
+			activeWorker:    1,
+			expectedType:    commonv1.JobFailed,
+		},
+	}
+	for _, c := range testCases {
+		config := &rest.Config{
+			Host: "",
+			ContentConfig: rest.ContentConfig{
+				GroupVersion: &tfv1.SchemeGroupVersion,
+			},
+		}
+		ctr, kubeInformerFactory, _ := newTFController(config, kubefake.NewSimpleClientset(),
+			volcanofake.NewSimpleClientset(), tfjobclientset.NewForConfigOrDie(config), 0, options.ServerOption{})
+		ctr.Recorder = &record.FakeRecorder{}
+		podIndexer := kubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+
+		initializeReplicaStatuses(&c.tfJob.Status.JobStatus, tfv1.TFReplicaTypeWorker)
+		setStatusForTest(c.tfJob, tfv1.TFReplicaTypeWorker, c.failedWorker, c.succeededWorker, c.activeWorker, false, c.worker0Completed, podIndexer, t)
+		if _, ok := c.tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeChief]; ok {
+			initializeReplicaStatuses(&c.tfJob.Status.JobStatus, tfv1.TFReplicaTypeChief)
+			setStatusForTest(c.tfJob, tfv1.TFReplicaTypeChief, 0, 0, c.activeChief, false, false, podIndexer, t)
+		}
+
+		if err := ctr.UpdateJobStatus(c.tfJob, c.tfJob.Spec.TFReplicaSpecs, &c.tfJob.Status.JobStatus); err != nil {
+			t.Errorf("%s: failed to update the job status: %v", c.description, err)
+			continue
+		}
+		conditions := c.tfJob.Status.Conditions
+		if len(conditions) == 0 || conditions[len(conditions)-1].Type != c.expectedType {
+			t.Errorf("%s: expected the last condition %s, got %v", c.description, c.expectedType, conditions)
+		} else if c.expectedReason != "" && conditions[len(conditions)-1].Reason != c.expectedReason {
+			t.Errorf("%s: expected the reason %s, got %v", c.description, c.expectedReason, conditions)
+		}
+	}
+}
+
+func withSuccessPolicy(tfJob *tfv1.TFJob, policy tfv1.SuccessPolicy) *tfv1.TFJob {
+	tfJob.Spec.SuccessPolicy = &policy
+	return tfJob
+}
+
+func withFailurePolicy(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, policy tfv1.FailurePolicy) *tfv1.TFJob {
+	tfJob.Spec.FailurePolicies = map[commonv1.ReplicaType]*tfv1.FailurePolicy{rtype: &policy}
+	return tfJob
+}
+
+func withChief(tfJob *tfv1.TFJob) *tfv1.TFJob {
+	tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeChief] = testutil.NewTFJobWithChief(0, 0).Spec.TFReplicaSpecs[tfv1.TFReplicaTypeChief]
+	return tfJob
+}
+
 func setStatusForTest(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, failed, succeeded, active int32, restart bool, worker0Completed bool, podIndexer cache.Indexer, t *testing.T) {
 	if restart == true {
 		tfJob.Spec.TFReplicaSpecs[rtype].RestartPolicy = commonv1.RestartPolicyExitCode
@@ -520,6 +863,8 @@ func setStatusForTest(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, failed, suc
 		typ = testutil.LabelPS
 	case tfv1.TFReplicaTypeChief:
 		typ = testutil.LabelChief
+	case tfv1.TFReplicaTypeCoordinator:
+		typ = testutil.LabelCoordinator
 	default:
 		fmt.Println("wrong type")
 	}
@@ -544,7 +889,7 @@ func setStatusForTest(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, failed, suc
 		if err := podIndexer.Add(pod); err != nil {
 			t.Errorf("%s: unexpected error when adding pod %v", tfJob.Name, err)
 		}
-		updateJobReplicaStatuses(&tfJob.Status, rtype, pod)
+		updateJobReplicaStatuses(&tfJob.Status.JobStatus, rtype, pod)
 
 		index++
 	}
@@ -568,7 +913,7 @@ func setStatusForTest(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, failed, suc
 		if err := podIndexer.Add(pod); err != nil {
 			t.Errorf("%s: unexpected error when adding pod %v", tfJob.Name, err)
 		}
-		updateJobReplicaStatuses(&tfJob.Status, rtype, pod)
+		updateJobReplicaStatuses(&tfJob.Status.JobStatus, rtype, pod)
 		index++
 	}
 	for i = 0; i < active; i++ {
@@ -577,7 +922,7 @@ func setStatusForTest(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, failed, suc
 		if err := podIndexer.Add(pod); err != nil {
 			t.Errorf("%s: unexpected error when adding pod %v", tfJob.Name, err)
 		}
-		updateJobReplicaStatuses(&tfJob.Status, rtype, pod)
+		updateJobReplicaStatuses(&tfJob.Status.JobStatus, rtype, pod)
 		index++
 	}
 }
@@ -590,3 +935,87 @@ func filterOutConditionTest(status commonv1.JobStatus, t *testing.T) {
 		}
 	}
 }
+
+func TestRecordReplicaPod(t *testing.T) {
+	tfJob := testutil.NewTFJob(2, 0)
+	rtype := tfv1.TFReplicaTypeWorker
+
+	pending := testutil.NewPod(tfJob, testutil.LabelWorker, 1)
+	pending.Status.Phase = v1.PodPending
+	pending.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name:  tfv1.DefaultContainerName,
+		State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+	}}
+	recordReplicaPod(&tfJob.Status, rtype, 1, pending, tfv1.DefaultContainerName)
+
+	failed := testutil.NewPod(tfJob, testutil.LabelWorker, 0)
+	failed.Spec.NodeName = "node-0"
+	failed.Status.Phase = v1.PodRunning
+	failed.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name:         tfv1.DefaultContainerName,
+		RestartCount: 2,
+		State:        v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+		},
+	}}
+	recordReplicaPod(&tfJob.Status, rtype, 0, failed, tfv1.DefaultContainerName)
+
+	statuses := tfJob.Status.ReplicaIndexStatuses[rtype]
+	if len(statuses) != 2 || statuses[0].Index != 0 || statuses[1].Index != 1 {
+		t.Fatalf("Expected the statuses of index 0 and 1 in order, got %v", statuses)
+	}
+	if s := statuses[1]; s.PodName != pending.Name || s.Phase != v1.PodPending || s.WaitingReason != "ImagePullBackOff" {
+		t.Errorf("Unexpected status of the pending pod: %+v", s)
+	}
+	s := statuses[0]
+	if s.NodeName != "node-0" || s.ContainerRestarts != 2 || s.WaitingReason != "CrashLoopBackOff" ||
+		s.LastExitCode == nil || *s.LastExitCode != 137 || s.TerminationReason != "OOMKilled" {
+		t.Errorf("Unexpected status of the failed pod: %+v", s)
+	}
+
+	// The state of the previous pod is cleared.
+	running := testutil.NewPod(tfJob, testutil.LabelWorker, 0)
+	running.Status.Phase = v1.PodRunning
+	recordReplicaPod(&tfJob.Status, rtype, 0, running, tfv1.DefaultContainerName)
+	s = tfJob.Status.ReplicaIndexStatuses[rtype][0]
+	if s.Phase != v1.PodRunning || s.LastExitCode != nil || s.TerminationReason != "" || s.WaitingReason != "" {
+		t.Errorf("Unexpected status of the running pod: %+v", s)
+	}
+
+	// The statuses of the indexes which are scaled down are removed.
+	pruneReplicaIndexStatuses(&tfJob.Status, rtype, 1)
+	if statuses := tfJob.Status.ReplicaIndexStatuses[rtype]; len(statuses) != 1 || statuses[0].Index != 0 {
+		t.Errorf("Expected only the status of index 0 after scaling down, got %v", statuses)
+	}
+	pruneReplicaIndexStatuses(&tfJob.Status, rtype, 0)
+	if _, ok := tfJob.Status.ReplicaIndexStatuses[rtype]; ok {
+		t.Errorf("Expected no statuses after scaling down to 0, got %v", tfJob.Status.ReplicaIndexStatuses[rtype])
+	}
+}
+
+func TestSetProgressCondition(t *testing.T) {
+	jobStatus := commonv1.JobStatus{}
+	if err := commonutil.UpdateJobConditions(&jobStatus, commonv1.JobRunning, tfJobRunningReason, "running"); err != nil {
+		t.Fatalf("Failed to update the conditions: %v", err)
+	}
+
+	// The phase of the tfjob stays the last condition.
+	if !setProgressCondition(&jobStatus, tfv1.JobWaiting, v1.ConditionTrue, tfJobWaitingReason, "waiting") {
+		t.Errorf("Expected the conditions to be changed")
+	}
+	conditions := jobStatus.Conditions
+	if len(conditions) != 2 || conditions[0].Type != tfv1.JobWaiting || conditions[1].Type != commonv1.JobRunning {
+		t.Fatalf("Expected the Waiting condition before the Running condition, got %v", conditions)
+	}
+	if setProgressCondition(&jobStatus, tfv1.JobWaiting, v1.ConditionTrue, tfJobWaitingReason, "waiting") {
+		t.Errorf("Expected the conditions to be unchanged")
+	}
+
+	// The condition is updated in place.
+	setProgressCondition(&jobStatus, tfv1.JobWaiting, v1.ConditionFalse, tfJobStartedReason, "started")
+	conditions = jobStatus.Conditions
+	if len(conditions) != 2 || conditions[0].Status != v1.ConditionFalse || conditions[0].Reason != tfJobStartedReason {
+		t.Errorf("Expected the Waiting condition to be false, got %v", conditions)
+	}
+}
@@ -18,16 +18,21 @@ package tensorflow
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"io/ioutil"
+	"net"
 	"strconv"
 	"strings"
 
+	v1 "k8s.io/api/core/v1"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	"github.com/kubeflow/common/pkg/controller.v1/common"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 )
 
 const (
-	// EnvCustomClusterDomain is the custom defined cluster domain, such as "svc.cluster.local".
+	// EnvCustomClusterDomain is the custom defined cluster domain, such as "cluster.local".
+	// It is used when the cluster domain is not set by the flag of the operator.
 	// Ref: https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#a-records
 	EnvCustomClusterDomain = "CUSTOM_CLUSTER_DOMAIN"
 )
@@ -94,14 +99,18 @@ func convertClusterSpecToSparseClusterSpec(clusterSpec ClusterSpec, rtype string
 //         },
 //     }
 // }
-func genTFConfigJSONStr(tfjob *tfv1.TFJob, rtype, index string) (string, error) {
-	// Configure the TFCONFIG environment variable.
-	i, err := strconv.ParseInt(index, 0, 32)
+func genTFConfigJSONStr(tfjob *tfv1.TFJob, rtype, index, clusterDomain string) (string, error) {
+	cluster, err := genClusterSpec(tfjob, clusterDomain)
 	if err != nil {
 		return "", err
 	}
+	return genTFConfigJSONStrWithCluster(tfjob, cluster, rtype, index)
+}
 
-	cluster, err := genClusterSpec(tfjob)
+// genTFConfigJSONStrWithCluster generates TF_CONFIG for the given cluster spec.
+func genTFConfigJSONStrWithCluster(tfjob *tfv1.TFJob, cluster ClusterSpec, rtype, index string) (string, error) {
+	// Configure the TFCONFIG environment variable.
+	i, err := strconv.ParseInt(index, 0, 32)
 	if err != nil {
 		return "", err
 	}
@@ -112,7 +121,7 @@ func genTFConfigJSONStr(tfjob *tfv1.TFJob, rtype, index string) (string, error)
 		sparseTFConfig := SparseTFConfig{
 			Cluster: sparseCluster,
 			Task: TaskSpec{
-				Type:  strings.ToLower(rtype),
+				Type:  tfConfigTaskType(rtype),
 				Index: int(i),
 			},
 		}
@@ -121,7 +130,7 @@ func genTFConfigJSONStr(tfjob *tfv1.TFJob, rtype, index string) (string, error)
 		tfConfig := TFConfig{
 			Cluster: cluster,
 			Task: TaskSpec{
-				Type:  strings.ToLower(rtype),
+				Type:  tfConfigTaskType(rtype),
 				Index: int(i),
 			},
 			// We need to set environment to cloud  otherwise it will default to local which isn't what we want.
@@ -138,11 +147,36 @@ func genTFConfigJSONStr(tfjob *tfv1.TFJob, rtype, index string) (string, error)
 	return string(tfConfigJSONByteSlice), nil
 }
 
-// genClusterSpec will generate ClusterSpec.
-func genClusterSpec(tfjob *tfv1.TFJob) (ClusterSpec, error) {
+// genTaskTFConfigJSONStr generates TF_CONFIG which only contains the task, for
+// TFConfigDeliveryConfigMap where the cluster spec is delivered in a file.
+func genTaskTFConfigJSONStr(rtype, index string) (string, error) {
+	i, err := strconv.ParseInt(index, 0, 32)
+	if err != nil {
+		return "", err
+	}
+	tfConfigJSONByteSlice, err := json.Marshal(TFConfig{
+		Cluster: ClusterSpec{},
+		Task: TaskSpec{
+			Type:  tfConfigTaskType(rtype),
+			Index: int(i),
+		},
+		Environment: "cloud",
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(tfConfigJSONByteSlice), nil
+}
+
+// genClusterSpec will generate ClusterSpec with the service names in the cluster domain,
+// or the hostnames of the pods in the Service of the tfjob for ClusterSpecModeHostname.
+func genClusterSpec(tfjob *tfv1.TFJob, clusterDomain string) (ClusterSpec, error) {
 	clusterSpec := make(ClusterSpec)
 
 	for rtype, spec := range tfjob.Spec.TFReplicaSpecs {
+		if !isClusterSpecMember(tfjob, rtype) {
+			continue
+		}
 		rt := strings.ToLower(string(rtype))
 		replicaNames := make([]string, 0, *spec.Replicas)
 
@@ -156,8 +190,10 @@ func genClusterSpec(tfjob *tfv1.TFJob) (ClusterSpec, error) {
 			// And the last part "svc.cluster.local" is called cluster domain
 			// which maybe different between kubernetes clusters.
 			hostName := common.GenGeneralName(tfjob.Name, rt, fmt.Sprintf("%d", i))
+			if isHostnameClusterSpec(tfjob) {
+				hostName += "." + genJobServiceName(tfjob)
+			}
 			svcName := hostName + "." + tfjob.Namespace + "." + "svc"
-			clusterDomain := os.Getenv(EnvCustomClusterDomain)
 			if len(clusterDomain) > 0 {
 				svcName += "." + clusterDomain
 			}
@@ -166,8 +202,107 @@ func genClusterSpec(tfjob *tfv1.TFJob) (ClusterSpec, error) {
 			replicaNames = append(replicaNames, endpoint)
 		}
 
-		clusterSpec[rt] = replicaNames
+		clusterSpec[tfConfigTaskType(rt)] = replicaNames
 	}
 
 	return clusterSpec, nil
 }
+
+// genClusterSpecFromPods will generate ClusterSpec from the addresses of the pods
+// for ClusterSpecModePodIP. It returns false if the address of any replica in the
+// cluster spec is not known yet.
+func genClusterSpecFromPods(tfjob *tfv1.TFJob, pods []*v1.Pod) (ClusterSpec, bool, error) {
+	clusterSpec := make(ClusterSpec)
+
+	for rtype, spec := range tfjob.Spec.TFReplicaSpecs {
+		if !isClusterSpecMember(tfjob, rtype) {
+			continue
+		}
+		rt := strings.ToLower(string(rtype))
+
+		port, err := GetPortFromTFJob(tfjob, rtype)
+		if err != nil {
+			return nil, false, err
+		}
+		addresses := make([]string, *spec.Replicas)
+		for _, pod := range pods {
+			if pod.Labels[tfReplicaTypeLabel] != rt || pod.DeletionTimestamp != nil {
+				continue
+			}
+			index, err := strconv.Atoi(pod.Labels[tfReplicaIndexLabel])
+			if err != nil || index < 0 || index >= len(addresses) {
+				continue
+			}
+			if ip := podAddress(pod); ip != "" {
+				addresses[index] = net.JoinHostPort(ip, strconv.Itoa(int(port)))
+			}
+		}
+		for _, address := range addresses {
+			if address == "" {
+				return nil, false, nil
+			}
+		}
+
+		clusterSpec[tfConfigTaskType(rt)] = addresses
+	}
+
+	return clusterSpec, true, nil
+}
+
+// podAddress returns the IP to reach the pod, which is the IP of its node
+// if the pod uses hostNetwork.
+func podAddress(pod *v1.Pod) string {
+	if pod.Spec.HostNetwork {
+		return pod.Status.HostIP
+	}
+	return pod.Status.PodIP
+}
+
+// isPodIPClusterSpec returns if the replicas are addressed by their pod IPs in TF_CONFIG.
+func isPodIPClusterSpec(tfjob *tfv1.TFJob) bool {
+	return tfjob.Spec.ClusterSpecMode != nil && *tfjob.Spec.ClusterSpecMode == tfv1.ClusterSpecModePodIP
+}
+
+// DetectClusterDomain detects the cluster domain from the search path in the
+// resolv.conf of the operator pod, e.g. "cluster.local" from "svc.cluster.local".
+// It returns an empty string if the search path has no cluster domain.
+func DetectClusterDomain(resolvConfPath string) (string, error) {
+	content, err := ioutil.ReadFile(resolvConfPath)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "search" {
+			continue
+		}
+		for _, domain := range fields[1:] {
+			domain = strings.TrimSuffix(domain, ".")
+			if strings.HasPrefix(domain, "svc.") {
+				return strings.TrimPrefix(domain, "svc."), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// tfConfigTaskType returns the task type of the replica type in TF_CONFIG. The
+// coordinator of ParameterServerStrategy is expected to be the "chief" task.
+func tfConfigTaskType(rtype string) string {
+	if strings.EqualFold(rtype, string(tfv1.TFReplicaTypeCoordinator)) {
+		return strings.ToLower(string(tfv1.TFReplicaTypeChief))
+	}
+	return strings.ToLower(rtype)
+}
+
+// isClusterSpecMember returns if the replicas of the type are members of the cluster spec.
+// Evaluator replicas are not members by default, since TensorFlow expects them to
+// run outside of the cluster and only receive their own task.
+func isClusterSpecMember(tfjob *tfv1.TFJob, rtype commonv1.ReplicaType) bool {
+	for t, member := range tfjob.Spec.ClusterSpecMembership {
+		if strings.EqualFold(string(t), string(rtype)) {
+			return member
+		}
+	}
+	return !strings.EqualFold(string(rtype), string(tfv1.TFReplicaTypeEval))
+}
@@ -16,8 +16,16 @@
 package tensorflow
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
 )
 
 func TestConvertClusterSpecToSparseClusterSpec(t *testing.T) {
@@ -43,3 +51,103 @@ func TestConvertClusterSpecToSparseClusterSpec(t *testing.T) {
 		t.Error("sparseClusterSpec for worker is not correct!")
 	}
 }
+
+func TestGenClusterSpecFromPods(t *testing.T) {
+	tfJob := testutil.NewTFJob(2, 1)
+	newPod := func(typ string, index int, ip string) *v1.Pod {
+		pod := testutil.NewPod(tfJob, typ, index)
+		pod.Status.PodIP = ip
+		return pod
+	}
+	hostNetworkPod := newPod(testutil.LabelWorker, 1, "10.0.0.2")
+	hostNetworkPod.Spec.HostNetwork = true
+	hostNetworkPod.Status.HostIP = "192.168.0.2"
+	deletingPod := newPod(testutil.LabelWorker, 1, "10.0.0.3")
+	deletingPod.DeletionTimestamp = &metav1.Time{}
+
+	testCases := map[string]struct {
+		pods          []*v1.Pod
+		expectedReady bool
+		expected      ClusterSpec
+	}{
+		"all addresses are known": {
+			pods: []*v1.Pod{
+				newPod(testutil.LabelWorker, 0, "10.0.0.1"),
+				hostNetworkPod,
+				newPod(testutil.LabelPS, 0, "fd00::1"),
+			},
+			expectedReady: true,
+			expected: ClusterSpec{
+				"worker": {"10.0.0.1:2222", "192.168.0.2:2222"},
+				"ps":     {"[fd00::1]:2222"},
+			},
+		},
+		"pod is not scheduled": {
+			pods: []*v1.Pod{
+				newPod(testutil.LabelWorker, 0, "10.0.0.1"),
+				newPod(testutil.LabelWorker, 1, ""),
+				newPod(testutil.LabelPS, 0, "10.0.0.4"),
+			},
+			expectedReady: false,
+		},
+		"pod is being deleted": {
+			pods: []*v1.Pod{
+				newPod(testutil.LabelWorker, 0, "10.0.0.1"),
+				deletingPod,
+				newPod(testutil.LabelPS, 0, "10.0.0.4"),
+			},
+			expectedReady: false,
+		},
+	}
+	for name, c := range testCases {
+		actual, ready, err := genClusterSpecFromPods(tfJob, c.pods)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if ready != c.expectedReady {
+			t.Errorf("%s: Expected ready %t, got %t", name, c.expectedReady, ready)
+		}
+		if c.expectedReady && !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: Expected %v, got %v", name, c.expected, actual)
+		}
+	}
+}
+
+func TestDetectClusterDomain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resolv")
+	if err != nil {
+		t.Fatalf("Failed to create the temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := map[string]struct {
+		resolvConf string
+		expected   string
+	}{
+		"kubernetes pod": {
+			resolvConf: "nameserver 10.96.0.10\nsearch kubeflow.svc.cluster.local svc.cluster.local cluster.local\noptions ndots:5\n",
+			expected:   "cluster.local",
+		},
+		"custom cluster domain": {
+			resolvConf: "search default.svc.tf.training.org. svc.tf.training.org. tf.training.org.\n",
+			expected:   "tf.training.org",
+		},
+		"no cluster domain": {
+			resolvConf: "nameserver 8.8.8.8\nsearch example.com\n",
+			expected:   "",
+		},
+	}
+	for name, c := range testCases {
+		resolvConfPath := filepath.Join(dir, "resolv.conf")
+		if err := ioutil.WriteFile(resolvConfPath, []byte(c.resolvConf), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", resolvConfPath, err)
+		}
+		actual, err := DetectClusterDomain(resolvConfPath)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if actual != c.expected {
+			t.Errorf("%s: Expected %q, got %q", name, c.expected, actual)
+		}
+	}
+}
@@ -16,9 +16,12 @@ package tensorflow
 
 import (
 	"fmt"
+	"strings"
 
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	train_util "github.com/kubeflow/common/pkg/util/train"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var (
@@ -27,12 +30,14 @@ var (
 
 // GetPortFromTFJob gets the port of tensorflow container.
 func GetPortFromTFJob(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType) (int32, error) {
-	containers := tfJob.Spec.TFReplicaSpecs[rtype].Template.Spec.Containers
-	for _, container := range containers {
-		if container.Name == tfv1.DefaultContainerName {
+	template := tfJob.Spec.TFReplicaSpecs[rtype].Template
+	containerName := tfv1.GetContainerName(&tfJob.Spec, template.Annotations)
+	portName := tfv1.GetPortName(&tfJob.Spec, template.Annotations)
+	for _, container := range template.Spec.Containers {
+		if container.Name == containerName {
 			ports := container.Ports
 			for _, port := range ports {
-				if port.Name == tfv1.DefaultPortName {
+				if port.Name == portName {
 					return port.ContainerPort, nil
 				}
 			}
@@ -50,3 +55,124 @@ func ContainChieforMasterSpec(replicas map[commonv1.ReplicaType]*commonv1.Replic
 	}
 	return false
 }
+
+// ContainCoordinatorSpec returns true if the tfjob contains coordinator spec.
+func ContainCoordinatorSpec(replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) bool {
+	_, ok := replicas[tfv1.TFReplicaTypeCoordinator]
+	return ok
+}
+
+// replicaTypeKey returns the key of the replica type in the specs, which is
+// compared case-insensitively since the stored specs are not camel-cased
+// unless they are defaulted by the admission webhook.
+func replicaTypeKey(replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec, rtype commonv1.ReplicaType) (commonv1.ReplicaType, bool) {
+	if _, ok := replicas[rtype]; ok {
+		return rtype, true
+	}
+	for t := range replicas {
+		if strings.EqualFold(string(t), string(rtype)) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// clampWorkerReplicas keeps the Worker replicas of an elastic TFJob within the
+// range of its ElasticPolicy. It returns true if the replicas are changed.
+func clampWorkerReplicas(tfJob *tfv1.TFJob) bool {
+	policy := tfJob.Spec.ElasticPolicy
+	workerType, ok := replicaTypeKey(tfJob.Spec.TFReplicaSpecs, tfv1.TFReplicaTypeWorker)
+	if policy == nil || !ok {
+		return false
+	}
+	spec := tfJob.Spec.TFReplicaSpecs[workerType]
+	if spec.Replicas == nil {
+		return false
+	}
+	replicas := *spec.Replicas
+	if policy.MinReplicas != nil && replicas < *policy.MinReplicas {
+		replicas = *policy.MinReplicas
+	}
+	if policy.MaxReplicas != nil && replicas > *policy.MaxReplicas {
+		replicas = *policy.MaxReplicas
+	}
+	if replicas == *spec.Replicas {
+		return false
+	}
+	spec.Replicas = &replicas
+	return true
+}
+
+// successThreshold returns the number of Worker replicas which need to succeed
+// to mark the tfjob as succeeded with the WorkerThreshold success policy.
+func successThreshold(tfJob *tfv1.TFJob, replicas map[commonv1.ReplicaType]*commonv1.ReplicaSpec) (int32, error) {
+	if tfJob.Spec.SuccessThreshold == nil {
+		return 0, fmt.Errorf("success threshold of TFJob %s is not set", tfJob.Name)
+	}
+	workers := int32(0)
+	if spec, ok := replicas[tfv1.TFReplicaTypeWorker]; ok && spec.Replicas != nil {
+		workers = *spec.Replicas
+	}
+	threshold, err := intstr.GetValueFromIntOrPercent(tfJob.Spec.SuccessThreshold, int(workers), true)
+	if err != nil {
+		return 0, err
+	}
+	// The workers of an elastic tfjob may be scaled below the threshold.
+	if int32(threshold) > workers {
+		return workers, nil
+	}
+	return int32(threshold), nil
+}
+
+// toleratesFailures returns the failure policy of the replica type, and whether
+// it tolerates the failed replicas of the type.
+func toleratesFailures(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, failed int32) (string, bool) {
+	policy, ok := tfJob.Spec.FailurePolicies[rtype]
+	if !ok || policy == nil {
+		return string(tfv1.FailurePolicyFailJob), false
+	}
+	switch policy.Type {
+	case tfv1.FailurePolicyIgnore:
+		return string(policy.Type), true
+	case tfv1.FailurePolicyTolerate:
+		maxFailures := int32(0)
+		if policy.MaxFailures != nil {
+			maxFailures = *policy.MaxFailures
+		}
+		return fmt.Sprintf("%s with maxFailures %d", policy.Type, maxFailures), failed <= maxFailures
+	default:
+		return string(tfv1.FailurePolicyFailJob), false
+	}
+}
+
+// isRetryableExitCode returns whether the replica of the type which exited with
+// the exit code should be restarted, and the rule which decided it.
+func isRetryableExitCode(tfJob *tfv1.TFJob, rtype commonv1.ReplicaType, exitCode int32) (bool, string) {
+	if policy := tfJob.Spec.ExitCodePolicies[rtype]; policy != nil {
+		if r, ok := matchExitCodeRange(policy.PermanentExitCodes, exitCode); ok {
+			return false, fmt.Sprintf("permanent exit codes %s of %s", r, rtype)
+		}
+		if r, ok := matchExitCodeRange(policy.RetryableExitCodes, exitCode); ok {
+			return true, fmt.Sprintf("retryable exit codes %s of %s", r, rtype)
+		}
+	}
+	return train_util.IsRetryableExitCode(exitCode), "default exit code rule"
+}
+
+// matchExitCodeRange returns the range containing the exit code, formatted as "min" or "min-max".
+func matchExitCodeRange(ranges []tfv1.ExitCodeRange, exitCode int32) (string, bool) {
+	for _, r := range ranges {
+		max := r.Min
+		if r.Max != nil {
+			max = *r.Max
+		}
+		if exitCode < r.Min || exitCode > max {
+			continue
+		}
+		if max == r.Min {
+			return fmt.Sprintf("%d", r.Min), true
+		}
+		return fmt.Sprintf("%d-%d", r.Min, max), true
+	}
+	return "", false
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
 	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
 )
@@ -79,3 +80,126 @@ func TestConvertTFJobToUnstructured(t *testing.T) {
 		t.Errorf("Expected error to be nil while got %v", err)
 	}
 }
+
+func TestClampWorkerReplicas(t *testing.T) {
+	testCases := map[string]struct {
+		replicas         int32
+		policy           *tfv1.ElasticPolicy
+		expectedChanged  bool
+		expectedReplicas int32
+	}{
+		"no elastic policy": {
+			replicas:         8,
+			policy:           nil,
+			expectedChanged:  false,
+			expectedReplicas: 8,
+		},
+		"within range": {
+			replicas:         3,
+			policy:           &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(2), MaxReplicas: tfv1.Int32(4)},
+			expectedChanged:  false,
+			expectedReplicas: 3,
+		},
+		"below min replicas": {
+			replicas:         1,
+			policy:           &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(2), MaxReplicas: tfv1.Int32(4)},
+			expectedChanged:  true,
+			expectedReplicas: 2,
+		},
+		"above max replicas": {
+			replicas:         8,
+			policy:           &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(2), MaxReplicas: tfv1.Int32(4)},
+			expectedChanged:  true,
+			expectedReplicas: 4,
+		},
+		"no max replicas": {
+			replicas:         8,
+			policy:           &tfv1.ElasticPolicy{MinReplicas: tfv1.Int32(2)},
+			expectedChanged:  false,
+			expectedReplicas: 8,
+		},
+	}
+	for name, c := range testCases {
+		tfJob := testutil.NewTFJob(int(c.replicas), 1)
+		tfJob.Spec.ElasticPolicy = c.policy
+		changed := clampWorkerReplicas(tfJob)
+		if changed != c.expectedChanged {
+			t.Errorf("%s: Expected changed %v, got %v", name, c.expectedChanged, changed)
+		}
+		actual := *tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker].Replicas
+		if actual != c.expectedReplicas {
+			t.Errorf("%s: Expected replicas %d, got %d", name, c.expectedReplicas, actual)
+		}
+	}
+}
+
+func TestIsRetryableExitCode(t *testing.T) {
+	policy := &tfv1.ExitCodePolicy{
+		RetryableExitCodes: []tfv1.ExitCodeRange{{Min: 1}, {Min: 130, Max: tfv1.Int32(143)}},
+		PermanentExitCodes: []tfv1.ExitCodeRange{{Min: 3}, {Min: 137}},
+	}
+	testCases := map[string]struct {
+		policy            *tfv1.ExitCodePolicy
+		exitCode          int32
+		expectedRetryable bool
+		expectedRule      string
+	}{
+		"default rule retries": {
+			policy:            nil,
+			exitCode:          130,
+			expectedRetryable: true,
+			expectedRule:      "default exit code rule",
+		},
+		"default rule doesn't retry": {
+			policy:            nil,
+			exitCode:          1,
+			expectedRetryable: false,
+			expectedRule:      "default exit code rule",
+		},
+		"retryable exit code": {
+			policy:            policy,
+			exitCode:          1,
+			expectedRetryable: true,
+			expectedRule:      "retryable exit codes 1 of Worker",
+		},
+		"retryable exit code range": {
+			policy:            policy,
+			exitCode:          135,
+			expectedRetryable: true,
+			expectedRule:      "retryable exit codes 130-143 of Worker",
+		},
+		"permanent exit code": {
+			policy:            policy,
+			exitCode:          3,
+			expectedRetryable: false,
+			expectedRule:      "permanent exit codes 3 of Worker",
+		},
+		"permanent exit code takes precedence": {
+			policy:            policy,
+			exitCode:          137,
+			expectedRetryable: false,
+			expectedRule:      "permanent exit codes 137 of Worker",
+		},
+		"unmatched exit code": {
+			policy:            policy,
+			exitCode:          127,
+			expectedRetryable: false,
+			expectedRule:      "default exit code rule",
+		},
+	}
+	for name, c := range testCases {
+		tfJob := testutil.NewTFJob(1, 0)
+		if c.policy != nil {
+			tfJob.Spec.ExitCodePolicies = map[commonv1.ReplicaType]*tfv1.ExitCodePolicy{
+				tfv1.TFReplicaTypeWorker: c.policy,
+			}
+		}
+		retryable, rule := isRetryableExitCode(tfJob, tfv1.TFReplicaTypeWorker, c.exitCode)
+		if retryable != c.expectedRetryable {
+			t.Errorf("%s: Expected retryable %v, got %v", name, c.expectedRetryable, retryable)
+		}
+		if rule != c.expectedRule {
+			t.Errorf("%s: Expected rule %q, got %q", name, c.expectedRule, rule)
+		}
+	}
+}
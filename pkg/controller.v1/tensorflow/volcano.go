@@ -0,0 +1,191 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensorflow
+
+import (
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned"
+
+	"github.com/kubeflow/common/pkg/controller.v1/common"
+	commonutil "github.com/kubeflow/common/pkg/util"
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+)
+
+const (
+	// defaultVolcanoSchedulerName is the name of the Volcano scheduler.
+	defaultVolcanoSchedulerName = "volcano"
+	// volcanoPodGroupAnnotation is the annotation key of the PodGroup of a pod
+	// scheduled by Volcano.
+	volcanoPodGroupAnnotation = "scheduling.k8s.io/group-name"
+	// volcanoMinTaskMemberAnnotation is the annotation of a PodGroup recording
+	// the minimum members of its tasks patched into its spec.
+	volcanoMinTaskMemberAnnotation = "kubeflow.org/min-task-member"
+)
+
+// volcanoScheduler gang-schedules tfjobs with the PodGroups of Volcano.
+type volcanoScheduler struct {
+	jc               *common.JobController
+	volcanoClientSet volcanoclient.Interface
+	schedulerName    string
+}
+
+func newVolcanoScheduler(jc *common.JobController, volcanoClientSet volcanoclient.Interface,
+	schedulerName string) *volcanoScheduler {
+
+	if schedulerName == "" {
+		schedulerName = defaultVolcanoSchedulerName
+	}
+	return &volcanoScheduler{
+		jc:               jc,
+		volcanoClientSet: volcanoClientSet,
+		schedulerName:    schedulerName,
+	}
+}
+
+func (s *volcanoScheduler) SchedulerName() string {
+	return s.schedulerName
+}
+
+func (s *volcanoScheduler) SupportsMinTaskMembers() bool {
+	return true
+}
+
+func (s *volcanoScheduler) SyncPodGroup(tfjob *tfv1.TFJob, spec podGroupSpec) (bool, string, error) {
+	podGroups := s.volcanoClientSet.SchedulingV1beta1().PodGroups(tfjob.Namespace)
+	pgSpec := v1beta1.PodGroupSpec{
+		MinMember:         spec.MinMember,
+		Queue:             spec.Queue,
+		PriorityClassName: spec.PriorityClass,
+		MinResources:      spec.MinResources,
+	}
+
+	podGroup, err := podGroups.Get(genPodGroupName(tfjob), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		commonutil.LoggerForJob(tfjob).Infof("Create Volcano PodGroup %s", genPodGroupName(tfjob))
+		podGroup, err = podGroups.Create(&v1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            genPodGroupName(tfjob),
+				Namespace:       tfjob.Namespace,
+				Annotations:     tfjob.Annotations,
+				Labels:          s.jc.GenLabels(tfjob.Name),
+				OwnerReferences: []metav1.OwnerReference{*s.jc.GenOwnerReference(tfjob)},
+			},
+			Spec: pgSpec,
+		})
+	} else if err == nil {
+		// Volcano puts the PodGroups without a queue into its default queue.
+		if pgSpec.Queue == "" {
+			pgSpec.Queue = podGroup.Spec.Queue
+		}
+		if !equality.Semantic.DeepEqual(podGroup.Spec, pgSpec) {
+			podGroup.Spec = pgSpec
+			// The update drops the minimum task members, which are patched again.
+			delete(podGroup.Annotations, volcanoMinTaskMemberAnnotation)
+			podGroup, err = podGroups.Update(podGroup)
+		}
+	}
+	if err == nil {
+		podGroup, err = s.syncMinTaskMember(tfjob, podGroup, spec.MinTaskMembers)
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	switch podGroup.Status.Phase {
+	case v1beta1.PodGroupInqueue, v1beta1.PodGroupRunning:
+		return true, "", nil
+	}
+	for _, condition := range podGroup.Status.Conditions {
+		if condition.Type == v1beta1.PodGroupUnschedulableType && condition.Status == v1.ConditionTrue {
+			return false, condition.Message, nil
+		}
+	}
+	return false, "", nil
+}
+
+// syncMinTaskMember sets the minimum members of the tasks of the PodGroup, i.e.
+// of the replica types named by the volcano.sh/task-spec annotation of the pods.
+// Volcano admits the PodGroup only once the minimum members of every task can be
+// scheduled. The field is only part of the Volcano API since volcano.sh/apis
+// v1.6.0, which requires Kubernetes 1.23 libraries, while this operator and
+// kubeflow/common are built against Kubernetes 1.16. Thus it is merge patched,
+// and recorded in an annotation to patch it only when it changes.
+func (s *volcanoScheduler) syncMinTaskMember(tfjob *tfv1.TFJob, podGroup *v1beta1.PodGroup,
+	minTaskMembers map[string]int32) (*v1beta1.PodGroup, error) {
+
+	recorded := podGroup.Annotations[volcanoMinTaskMemberAnnotation]
+	var annotation interface{}
+	minTaskMember := map[string]interface{}{}
+	if len(minTaskMembers) > 0 {
+		data, err := json.Marshal(minTaskMembers)
+		if err != nil {
+			return nil, err
+		}
+		if string(data) == recorded {
+			return podGroup, nil
+		}
+		annotation = string(data)
+		for task, minMember := range minTaskMembers {
+			minTaskMember[task] = minMember
+		}
+	} else if recorded == "" {
+		return podGroup, nil
+	}
+	// The tasks which are no longer members are removed.
+	var previous map[string]int32
+	if recorded != "" && json.Unmarshal([]byte(recorded), &previous) == nil {
+		for task := range previous {
+			if _, ok := minTaskMember[task]; !ok {
+				minTaskMember[task] = nil
+			}
+		}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{volcanoMinTaskMemberAnnotation: annotation},
+		},
+		"spec": map[string]interface{}{"minTaskMember": minTaskMember},
+	})
+	if err != nil {
+		return nil, err
+	}
+	commonutil.LoggerForJob(tfjob).Infof("Set the minimum task members of Volcano PodGroup %s to %v",
+		podGroup.Name, minTaskMembers)
+	return s.volcanoClientSet.SchedulingV1beta1().PodGroups(podGroup.Namespace).Patch(podGroup.Name, types.MergePatchType, patch)
+}
+
+func (s *volcanoScheduler) DeletePodGroup(tfjob *tfv1.TFJob) error {
+	err := s.volcanoClientSet.SchedulingV1beta1().PodGroups(tfjob.Namespace).Delete(genPodGroupName(tfjob), &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *volcanoScheduler) DecoratePodTemplate(tfjob *tfv1.TFJob, podTemplate *v1.PodTemplateSpec, rt string) {
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	podTemplate.Annotations[volcanoPodGroupAnnotation] = genPodGroupName(tfjob)
+	podTemplate.Annotations[volcanoTaskSpecKey] = rt
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+const (
+	// CertFileName is the name of the serving certificate in the cert dir.
+	CertFileName = "tls.crt"
+	// KeyFileName is the name of the serving key in the cert dir.
+	KeyFileName = "tls.key"
+)
+
+// Server serves the admission webhooks over HTTPS.
+type Server struct {
+	// Port is the port the server listens on.
+	Port int
+	// CertDir is the directory containing tls.crt and tls.key. If they
+	// don't exist, a self-signed certificate for Host is generated there.
+	CertDir string
+	// Host is the host name the self-signed certificate is issued for.
+	Host string
+}
+
+// Run serves the admission webhooks until stopCh is closed.
+func (s *Server) Run(stopCh <-chan struct{}) error {
+	certFile, keyFile, err := s.ensureCert()
+	if err != nil {
+		return err
+	}
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the webhook certificate: %v", err)
+	}
+
+	srv := &http.Server{
+		Addr:      fmt.Sprintf(":%d", s.Port),
+		Handler:   NewHandler(),
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{pair}},
+	}
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Errorf("Failed to shut down the webhook server: %v", err)
+		}
+	}()
+
+	log.Infof("Serving admission webhooks on port %d", s.Port)
+	if err := srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// ensureCert returns the paths of the serving certificate and key, generating
+// a self-signed pair if the cert dir doesn't contain one.
+func (s *Server) ensureCert() (string, string, error) {
+	certFile := filepath.Join(s.CertDir, CertFileName)
+	keyFile := filepath.Join(s.CertDir, KeyFileName)
+	if fileExists(certFile) && fileExists(keyFile) {
+		log.Infof("Using the webhook certificate in %s", s.CertDir)
+		return certFile, keyFile, nil
+	}
+
+	log.Infof("Generating a self-signed webhook certificate for %s in %s", s.Host, s.CertDir)
+	certPEM, keyPEM, err := cert.GenerateSelfSignedCertKey(s.Host, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate the webhook certificate: %v", err)
+	}
+	if err := cert.WriteCert(certFile, certPEM); err != nil {
+		return "", "", fmt.Errorf("failed to write the webhook certificate: %v", err)
+	}
+	if err := keyutil.WriteKey(keyFile, keyPEM); err != nil {
+		return "", "", fmt.Errorf("failed to write the webhook key: %v", err)
+	}
+	return certFile, keyFile, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
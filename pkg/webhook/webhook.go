@@ -0,0 +1,192 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook implements the admission webhooks for TFJobs.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	"github.com/kubeflow/tf-operator/pkg/apis/tensorflow/validation"
+)
+
+const (
+	// ValidatePath is the path of the validating webhook for TFJobs.
+	ValidatePath = "/validate-tfjob"
+	// MutatePath is the path of the mutating webhook for TFJobs.
+	MutatePath = "/mutate-tfjob"
+)
+
+var tfJobResource = metav1.GroupVersionResource{
+	Group:    tfv1.GroupName,
+	Version:  tfv1.GroupVersion,
+	Resource: tfv1.Plural,
+}
+
+// admitFunc handles an admission request and returns the admission response.
+type admitFunc func(*admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse
+
+// NewHandler returns the http handler serving the admission webhooks.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(ValidatePath, admitHandler(validateTFJob))
+	mux.Handle(MutatePath, admitHandler(mutateTFJob))
+	return mux
+}
+
+// admitHandler decodes the AdmissionReview in the request body, calls admit
+// and writes the AdmissionReview with the response back.
+func admitHandler(admit admitFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			http.Error(w, fmt.Sprintf("content type %s is not supported, expected application/json", contentType),
+				http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read the request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		review := admissionv1beta1.AdmissionReview{}
+		if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+			http.Error(w, fmt.Sprintf("failed to decode the admission review: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		response := admit(review.Request)
+		response.UID = review.Request.UID
+		review.Response = response
+		review.Request = nil
+
+		resp, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to encode the admission review: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(resp); err != nil {
+			log.Errorf("Failed to write the admission response: %v", err)
+		}
+	}
+}
+
+// validateTFJob validates the TFJob in the admission request.
+func validateTFJob(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if req.Resource != tfJobResource {
+		return toErrorResponse(apierrors.NewBadRequest(
+			fmt.Sprintf("expected resource %v, got %v", tfJobResource, req.Resource)))
+	}
+
+	tfJob := &tfv1.TFJob{}
+	if err := json.Unmarshal(req.Object.Raw, tfJob); err != nil {
+		return toErrorResponse(apierrors.NewBadRequest(fmt.Sprintf("failed to decode the TFJob: %v", err)))
+	}
+	if tfJob.Name == "" {
+		// The name is not populated yet when generateName is used.
+		tfJob.Name = req.Name
+	}
+
+	var allErrs field.ErrorList
+	switch req.Operation {
+	case admissionv1beta1.Create:
+		allErrs = validation.ValidateV1TFJob(tfJob)
+	case admissionv1beta1.Update:
+		oldTFJob := &tfv1.TFJob{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldTFJob); err != nil {
+			return toErrorResponse(apierrors.NewBadRequest(fmt.Sprintf("failed to decode the old TFJob: %v", err)))
+		}
+		allErrs = validation.ValidateV1TFJobUpdate(tfJob, oldTFJob)
+	default:
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	if len(allErrs) != 0 {
+		log.Infof("Rejected %s of TFJob %s/%s: %v", req.Operation, req.Namespace, tfJob.Name, allErrs.ToAggregate())
+		return toErrorResponse(apierrors.NewInvalid(
+			schema.GroupKind{Group: tfv1.GroupName, Kind: tfv1.Kind}, tfJob.Name, allErrs))
+	}
+	return &admissionv1beta1.AdmissionResponse{Allowed: true}
+}
+
+// mutateTFJob persists the defaults of the TFJob in the admission request, so
+// that the stored spec is the one the controller acts on.
+func mutateTFJob(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if req.Resource != tfJobResource {
+		return toErrorResponse(apierrors.NewBadRequest(
+			fmt.Sprintf("expected resource %v, got %v", tfJobResource, req.Resource)))
+	}
+	if req.Operation != admissionv1beta1.Create && req.Operation != admissionv1beta1.Update {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	tfJob := &tfv1.TFJob{}
+	if err := json.Unmarshal(req.Object.Raw, tfJob); err != nil {
+		return toErrorResponse(apierrors.NewBadRequest(fmt.Sprintf("failed to decode the TFJob: %v", err)))
+	}
+	defaulted := tfJob.DeepCopy()
+	tfv1.SetObjectDefaults_TFJob(defaulted)
+	if equality.Semantic.DeepEqual(tfJob.Spec, defaulted.Spec) {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	// The defaults only touch the spec, replace it as a whole instead of
+	// diffing every field.
+	patch, err := json.Marshal([]jsonPatchOperation{{
+		Op:    "replace",
+		Path:  "/spec",
+		Value: defaulted.Spec,
+	}})
+	if err != nil {
+		return toErrorResponse(apierrors.NewInternalError(err))
+	}
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// jsonPatchOperation is an RFC 6902 JSON patch operation.
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func toErrorResponse(err *apierrors.StatusError) *admissionv1beta1.AdmissionResponse {
+	status := err.ErrStatus
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2021 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	tfv1 "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
+	"github.com/kubeflow/tf-operator/pkg/common/util/v1/testutil"
+)
+
+func review(t *testing.T, path string, operation admissionv1beta1.Operation, tfJob, oldTFJob *tfv1.TFJob) *admissionv1beta1.AdmissionResponse {
+	raw := func(obj *tfv1.TFJob) runtime.RawExtension {
+		if obj == nil {
+			return runtime.RawExtension{}
+		}
+		b, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("Failed to marshal the TFJob: %v", err)
+		}
+		return runtime.RawExtension{Raw: b}
+	}
+	body, err := json.Marshal(admissionv1beta1.AdmissionReview{
+		Request: &admissionv1beta1.AdmissionRequest{
+			UID:       types.UID("test-uid"),
+			Resource:  tfJobResource,
+			Operation: operation,
+			Namespace: tfJob.Namespace,
+			Name:      tfJob.Name,
+			Object:    raw(tfJob),
+			OldObject: raw(oldTFJob),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal the admission review: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	NewHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	resp := admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode the admission review: %v", err)
+	}
+	if resp.Response == nil || resp.Response.UID != "test-uid" {
+		t.Fatalf("Expected a response for uid test-uid, got %v", resp.Response)
+	}
+	return resp.Response
+}
+
+func TestValidateTFJob(t *testing.T) {
+	testCases := map[string]struct {
+		operation       admissionv1beta1.Operation
+		tfJob           func() *tfv1.TFJob
+		oldTFJob        func() *tfv1.TFJob
+		expectedAllowed bool
+		expectedField   string
+	}{
+		"valid tfjob": {
+			operation:       admissionv1beta1.Create,
+			tfJob:           func() *tfv1.TFJob { return testutil.NewTFJob(2, 1) },
+			expectedAllowed: true,
+		},
+		"missing image": {
+			operation: admissionv1beta1.Create,
+			tfJob: func() *tfv1.TFJob {
+				tfJob := testutil.NewTFJob(2, 1)
+				tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypePS].Template.Spec.Containers[0].Image = ""
+				return tfJob
+			},
+			expectedAllowed: false,
+			expectedField:   "spec.tfReplicaSpecs[PS].template.spec.containers[0].image",
+		},
+		"scale workers": {
+			operation:       admissionv1beta1.Update,
+			tfJob:           func() *tfv1.TFJob { return testutil.NewTFJob(4, 1) },
+			oldTFJob:        func() *tfv1.TFJob { return testutil.NewTFJob(2, 1) },
+			expectedAllowed: true,
+		},
+		"remove parameter servers": {
+			operation:       admissionv1beta1.Update,
+			tfJob:           func() *tfv1.TFJob { return testutil.NewTFJob(2, 0) },
+			oldTFJob:        func() *tfv1.TFJob { return testutil.NewTFJob(2, 1) },
+			expectedAllowed: false,
+			expectedField:   "spec.tfReplicaSpecs[PS]",
+		},
+	}
+
+	for name, c := range testCases {
+		var oldTFJob *tfv1.TFJob
+		if c.oldTFJob != nil {
+			oldTFJob = c.oldTFJob()
+		}
+		resp := review(t, ValidatePath, c.operation, c.tfJob(), oldTFJob)
+		if resp.Allowed != c.expectedAllowed {
+			t.Errorf("%s: Expected allowed %v, got %v: %v", name, c.expectedAllowed, resp.Allowed, resp.Result)
+			continue
+		}
+		if c.expectedAllowed {
+			continue
+		}
+		if resp.Result == nil || resp.Result.Details == nil || len(resp.Result.Details.Causes) == 0 {
+			t.Errorf("%s: Expected the causes of the rejection, got %v", name, resp.Result)
+			continue
+		}
+		if field := resp.Result.Details.Causes[0].Field; field != c.expectedField {
+			t.Errorf("%s: Expected field %s, got %s", name, c.expectedField, field)
+		}
+	}
+}
+
+func TestMutateTFJob(t *testing.T) {
+	tfJob := testutil.NewTFJob(2, 1)
+	tfJob.Spec.TFReplicaSpecs["WORKER"] = tfJob.Spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+	delete(tfJob.Spec.TFReplicaSpecs, tfv1.TFReplicaTypeWorker)
+
+	resp := review(t, MutatePath, admissionv1beta1.Create, tfJob, nil)
+	if !resp.Allowed {
+		t.Fatalf("Expected the TFJob to be allowed, got %v", resp.Result)
+	}
+	if resp.PatchType == nil || *resp.PatchType != admissionv1beta1.PatchTypeJSONPatch {
+		t.Fatalf("Expected a JSON patch, got %v", resp.PatchType)
+	}
+
+	var patch []struct {
+		Op    string         `json:"op"`
+		Path  string         `json:"path"`
+		Value tfv1.TFJobSpec `json:"value"`
+	}
+	if err := json.Unmarshal(resp.Patch, &patch); err != nil {
+		t.Fatalf("Failed to decode the patch: %v", err)
+	}
+	if len(patch) != 1 || patch[0].Op != "replace" || patch[0].Path != "/spec" {
+		t.Fatalf("Expected the spec to be replaced, got %s", string(resp.Patch))
+	}
+	spec := patch[0].Value
+	worker, ok := spec.TFReplicaSpecs[tfv1.TFReplicaTypeWorker]
+	if !ok {
+		t.Fatalf("Expected replica type %s to be camel-cased, got %v", tfv1.TFReplicaTypeWorker, spec.TFReplicaSpecs)
+	}
+	if worker.RestartPolicy != tfv1.DefaultRestartPolicy {
+		t.Errorf("Expected restart policy %s, got %s", tfv1.DefaultRestartPolicy, worker.RestartPolicy)
+	}
+	if spec.RunPolicy.CleanPodPolicy == nil || spec.SuccessPolicy == nil {
+		t.Errorf("Expected the clean pod policy and success policy to be defaulted, got %v", spec)
+	}
+
+	// A TFJob which is already defaulted is not patched.
+	tfv1.SetObjectDefaults_TFJob(tfJob)
+	resp = review(t, MutatePath, admissionv1beta1.Create, tfJob, nil)
+	if !resp.Allowed || len(resp.Patch) != 0 {
+		t.Errorf("Expected no patch for a defaulted TFJob, got %s", string(resp.Patch))
+	}
+}
+
+func TestAdmitHandlerRejectsBadRequests(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader([]byte("{")))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	NewHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, ValidatePath, nil)
+	w = httptest.NewRecorder()
+	NewHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status code %d, got %d", http.StatusMethodNotAllowed, w.Code)
+	}
+}
@@ -25,10 +25,22 @@ from kubeflow.tfjob.configuration import Configuration
 from kubeflow.tfjob.api.tf_job_client import TFJobClient
 
 # import models into sdk package
+from kubeflow.tfjob.models.v1_elastic_policy import V1ElasticPolicy
+from kubeflow.tfjob.models.v1_exit_code_policy import V1ExitCodePolicy
+from kubeflow.tfjob.models.v1_exit_code_range import V1ExitCodeRange
+from kubeflow.tfjob.models.v1_failure_policy import V1FailurePolicy
 from kubeflow.tfjob.models.v1_job_condition import V1JobCondition
 from kubeflow.tfjob.models.v1_job_status import V1JobStatus
+from kubeflow.tfjob.models.v1_memory_escalation_policy import V1MemoryEscalationPolicy
+from kubeflow.tfjob.models.v1_pending_timeout_policy import V1PendingTimeoutPolicy
+from kubeflow.tfjob.models.v1_pod_group_status import V1PodGroupStatus
+from kubeflow.tfjob.models.v1_replica_index_status import V1ReplicaIndexStatus
 from kubeflow.tfjob.models.v1_replica_spec import V1ReplicaSpec
 from kubeflow.tfjob.models.v1_replica_status import V1ReplicaStatus
+from kubeflow.tfjob.models.v1_run_policy import V1RunPolicy
+from kubeflow.tfjob.models.v1_scheduling_policy import V1SchedulingPolicy
+from kubeflow.tfjob.models.v1_startup_policy import V1StartupPolicy
 from kubeflow.tfjob.models.v1_tf_job import V1TFJob
 from kubeflow.tfjob.models.v1_tf_job_list import V1TFJobList
 from kubeflow.tfjob.models.v1_tf_job_spec import V1TFJobSpec
+from kubeflow.tfjob.models.v1_tf_job_status import V1TFJobStatus
@@ -0,0 +1,159 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+
+class V1ElasticPolicy(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'max_replicas': 'int',
+        'min_replicas': 'int'
+    }
+
+    attribute_map = {
+        'max_replicas': 'maxReplicas',
+        'min_replicas': 'minReplicas'
+    }
+
+    def __init__(self, max_replicas=None, min_replicas=None):  # noqa: E501
+        """V1ElasticPolicy - a model defined in Swagger"""  # noqa: E501
+
+        self._max_replicas = None
+        self._min_replicas = None
+        self.discriminator = None
+
+        if max_replicas is not None:
+            self.max_replicas = max_replicas
+        if min_replicas is not None:
+            self.min_replicas = min_replicas
+
+    @property
+    def max_replicas(self):
+        """Gets the max_replicas of this V1ElasticPolicy.  # noqa: E501
+
+        MaxReplicas is the upper limit for the number of Worker replicas. If not set, the number of Worker replicas is not bounded above.  # noqa: E501
+
+        :return: The max_replicas of this V1ElasticPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_replicas
+
+    @max_replicas.setter
+    def max_replicas(self, max_replicas):
+        """Sets the max_replicas of this V1ElasticPolicy.
+
+        MaxReplicas is the upper limit for the number of Worker replicas. If not set, the number of Worker replicas is not bounded above.  # noqa: E501
+
+        :param max_replicas: The max_replicas of this V1ElasticPolicy.  # noqa: E501
+        :type: int
+        """
+
+        self._max_replicas = max_replicas
+
+    @property
+    def min_replicas(self):
+        """Gets the min_replicas of this V1ElasticPolicy.  # noqa: E501
+
+        MinReplicas is the lower limit for the number of Worker replicas. Default to 1.  # noqa: E501
+
+        :return: The min_replicas of this V1ElasticPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._min_replicas
+
+    @min_replicas.setter
+    def min_replicas(self, min_replicas):
+        """Sets the min_replicas of this V1ElasticPolicy.
+
+        MinReplicas is the lower limit for the number of Worker replicas. Default to 1.  # noqa: E501
+
+        :param min_replicas: The min_replicas of this V1ElasticPolicy.  # noqa: E501
+        :type: int
+        """
+
+        self._min_replicas = min_replicas
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1ElasticPolicy, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1ElasticPolicy):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
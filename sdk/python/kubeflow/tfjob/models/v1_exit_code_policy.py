@@ -0,0 +1,161 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kubeflow.tfjob.models.v1_exit_code_range import V1ExitCodeRange  # noqa: F401,E501
+
+
+class V1ExitCodePolicy(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'permanent_exit_codes': 'list[V1ExitCodeRange]',
+        'retryable_exit_codes': 'list[V1ExitCodeRange]'
+    }
+
+    attribute_map = {
+        'permanent_exit_codes': 'permanentExitCodes',
+        'retryable_exit_codes': 'retryableExitCodes'
+    }
+
+    def __init__(self, permanent_exit_codes=None, retryable_exit_codes=None):  # noqa: E501
+        """V1ExitCodePolicy - a model defined in Swagger"""  # noqa: E501
+
+        self._permanent_exit_codes = None
+        self._retryable_exit_codes = None
+        self.discriminator = None
+
+        if permanent_exit_codes is not None:
+            self.permanent_exit_codes = permanent_exit_codes
+        if retryable_exit_codes is not None:
+            self.retryable_exit_codes = retryable_exit_codes
+
+    @property
+    def permanent_exit_codes(self):
+        """Gets the permanent_exit_codes of this V1ExitCodePolicy.  # noqa: E501
+
+        PermanentExitCodes are the exit codes which fail the replica. They take precedence over RetryableExitCodes.  # noqa: E501
+
+        :return: The permanent_exit_codes of this V1ExitCodePolicy.  # noqa: E501
+        :rtype: list[V1ExitCodeRange]
+        """
+        return self._permanent_exit_codes
+
+    @permanent_exit_codes.setter
+    def permanent_exit_codes(self, permanent_exit_codes):
+        """Sets the permanent_exit_codes of this V1ExitCodePolicy.
+
+        PermanentExitCodes are the exit codes which fail the replica. They take precedence over RetryableExitCodes.  # noqa: E501
+
+        :param permanent_exit_codes: The permanent_exit_codes of this V1ExitCodePolicy.  # noqa: E501
+        :type: list[V1ExitCodeRange]
+        """
+
+        self._permanent_exit_codes = permanent_exit_codes
+
+    @property
+    def retryable_exit_codes(self):
+        """Gets the retryable_exit_codes of this V1ExitCodePolicy.  # noqa: E501
+
+        RetryableExitCodes are the exit codes which restart the replica.  # noqa: E501
+
+        :return: The retryable_exit_codes of this V1ExitCodePolicy.  # noqa: E501
+        :rtype: list[V1ExitCodeRange]
+        """
+        return self._retryable_exit_codes
+
+    @retryable_exit_codes.setter
+    def retryable_exit_codes(self, retryable_exit_codes):
+        """Sets the retryable_exit_codes of this V1ExitCodePolicy.
+
+        RetryableExitCodes are the exit codes which restart the replica.  # noqa: E501
+
+        :param retryable_exit_codes: The retryable_exit_codes of this V1ExitCodePolicy.  # noqa: E501
+        :type: list[V1ExitCodeRange]
+        """
+
+        self._retryable_exit_codes = retryable_exit_codes
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1ExitCodePolicy, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1ExitCodePolicy):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
@@ -0,0 +1,159 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+
+class V1FailurePolicy(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'max_failures': 'int',
+        'type': 'str'
+    }
+
+    attribute_map = {
+        'max_failures': 'maxFailures',
+        'type': 'type'
+    }
+
+    def __init__(self, max_failures=None, type=None):  # noqa: E501
+        """V1FailurePolicy - a model defined in Swagger"""  # noqa: E501
+
+        self._max_failures = None
+        self._type = None
+        self.discriminator = None
+
+        if max_failures is not None:
+            self.max_failures = max_failures
+        if type is not None:
+            self.type = type
+
+    @property
+    def max_failures(self):
+        """Gets the max_failures of this V1FailurePolicy.  # noqa: E501
+
+        MaxFailures is the number of failed replicas tolerated by the \"Tolerate\" type.  # noqa: E501
+
+        :return: The max_failures of this V1FailurePolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_failures
+
+    @max_failures.setter
+    def max_failures(self, max_failures):
+        """Sets the max_failures of this V1FailurePolicy.
+
+        MaxFailures is the number of failed replicas tolerated by the \"Tolerate\" type.  # noqa: E501
+
+        :param max_failures: The max_failures of this V1FailurePolicy.  # noqa: E501
+        :type: int
+        """
+
+        self._max_failures = max_failures
+
+    @property
+    def type(self):
+        """Gets the type of this V1FailurePolicy.  # noqa: E501
+
+        Type is the type of the failure policy. One of \"FailJob\", \"Ignore\" or \"Tolerate\". Default to \"FailJob\".  # noqa: E501
+
+        :return: The type of this V1FailurePolicy.  # noqa: E501
+        :rtype: str
+        """
+        return self._type
+
+    @type.setter
+    def type(self, type):
+        """Sets the type of this V1FailurePolicy.
+
+        Type is the type of the failure policy. One of \"FailJob\", \"Ignore\" or \"Tolerate\". Default to \"FailJob\".  # noqa: E501
+
+        :param type: The type of this V1FailurePolicy.  # noqa: E501
+        :type: str
+        """
+
+        self._type = type
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1FailurePolicy, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1FailurePolicy):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
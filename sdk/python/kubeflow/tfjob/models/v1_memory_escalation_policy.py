@@ -0,0 +1,161 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+
+class V1MemoryEscalationPolicy(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'factor_percent': 'int',
+        'max_memory': 'str'
+    }
+
+    attribute_map = {
+        'factor_percent': 'factorPercent',
+        'max_memory': 'maxMemory'
+    }
+
+    def __init__(self, factor_percent=None, max_memory=None):  # noqa: E501
+        """V1MemoryEscalationPolicy - a model defined in Swagger"""  # noqa: E501
+
+        self._factor_percent = None
+        self._max_memory = None
+        self.discriminator = None
+
+        self.factor_percent = factor_percent
+        self.max_memory = max_memory
+
+    @property
+    def factor_percent(self):
+        """Gets the factor_percent of this V1MemoryEscalationPolicy.  # noqa: E501
+
+        FactorPercent is the percentage of the memory request and limit of the killed replica which the recreated replica gets, e.g. 150 raises them by half. It must be greater than 100.  # noqa: E501
+
+        :return: The factor_percent of this V1MemoryEscalationPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._factor_percent
+
+    @factor_percent.setter
+    def factor_percent(self, factor_percent):
+        """Sets the factor_percent of this V1MemoryEscalationPolicy.
+
+        FactorPercent is the percentage of the memory request and limit of the killed replica which the recreated replica gets, e.g. 150 raises them by half. It must be greater than 100.  # noqa: E501
+
+        :param factor_percent: The factor_percent of this V1MemoryEscalationPolicy.  # noqa: E501
+        :type: int
+        """
+        if factor_percent is None:
+            raise ValueError("Invalid value for `factor_percent`, must not be `None`")  # noqa: E501
+
+        self._factor_percent = factor_percent
+
+    @property
+    def max_memory(self):
+        """Gets the max_memory of this V1MemoryEscalationPolicy.  # noqa: E501
+
+        MaxMemory is the ceiling of the raised memory request and limit.  # noqa: E501
+
+        :return: The max_memory of this V1MemoryEscalationPolicy.  # noqa: E501
+        :rtype: str
+        """
+        return self._max_memory
+
+    @max_memory.setter
+    def max_memory(self, max_memory):
+        """Sets the max_memory of this V1MemoryEscalationPolicy.
+
+        MaxMemory is the ceiling of the raised memory request and limit.  # noqa: E501
+
+        :param max_memory: The max_memory of this V1MemoryEscalationPolicy.  # noqa: E501
+        :type: str
+        """
+        if max_memory is None:
+            raise ValueError("Invalid value for `max_memory`, must not be `None`")  # noqa: E501
+
+        self._max_memory = max_memory
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1MemoryEscalationPolicy, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1MemoryEscalationPolicy):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
@@ -0,0 +1,160 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+
+class V1PendingTimeoutPolicy(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'action': 'str',
+        'timeout_seconds': 'int'
+    }
+
+    attribute_map = {
+        'action': 'action',
+        'timeout_seconds': 'timeoutSeconds'
+    }
+
+    def __init__(self, action=None, timeout_seconds=None):  # noqa: E501
+        """V1PendingTimeoutPolicy - a model defined in Swagger"""  # noqa: E501
+
+        self._action = None
+        self._timeout_seconds = None
+        self.discriminator = None
+
+        if action is not None:
+            self.action = action
+        self.timeout_seconds = timeout_seconds
+
+    @property
+    def action(self):
+        """Gets the action of this V1PendingTimeoutPolicy.  # noqa: E501
+
+        Action is the action taken when the timeout expires. One of \"Fail\" or \"Suspend\". Default to \"Fail\".  # noqa: E501
+
+        :return: The action of this V1PendingTimeoutPolicy.  # noqa: E501
+        :rtype: str
+        """
+        return self._action
+
+    @action.setter
+    def action(self, action):
+        """Sets the action of this V1PendingTimeoutPolicy.
+
+        Action is the action taken when the timeout expires. One of \"Fail\" or \"Suspend\". Default to \"Fail\".  # noqa: E501
+
+        :param action: The action of this V1PendingTimeoutPolicy.  # noqa: E501
+        :type: str
+        """
+
+        self._action = action
+
+    @property
+    def timeout_seconds(self):
+        """Gets the timeout_seconds of this V1PendingTimeoutPolicy.  # noqa: E501
+
+        TimeoutSeconds is how long a replica may stay Pending since it is created.  # noqa: E501
+
+        :return: The timeout_seconds of this V1PendingTimeoutPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._timeout_seconds
+
+    @timeout_seconds.setter
+    def timeout_seconds(self, timeout_seconds):
+        """Sets the timeout_seconds of this V1PendingTimeoutPolicy.
+
+        TimeoutSeconds is how long a replica may stay Pending since it is created.  # noqa: E501
+
+        :param timeout_seconds: The timeout_seconds of this V1PendingTimeoutPolicy.  # noqa: E501
+        :type: int
+        """
+        if timeout_seconds is None:
+            raise ValueError("Invalid value for `timeout_seconds`, must not be `None`")  # noqa: E501
+
+        self._timeout_seconds = timeout_seconds
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1PendingTimeoutPolicy, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1PendingTimeoutPolicy):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
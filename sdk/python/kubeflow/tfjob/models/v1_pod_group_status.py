@@ -0,0 +1,189 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+
+class V1PodGroupStatus(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'admitted': 'bool',
+        'message': 'str',
+        'name': 'str'
+    }
+
+    attribute_map = {
+        'admitted': 'admitted',
+        'message': 'message',
+        'name': 'name'
+    }
+
+    def __init__(self, admitted=None, message=None, name=None):  # noqa: E501
+        """V1PodGroupStatus - a model defined in Swagger"""  # noqa: E501
+
+        self._admitted = None
+        self._message = None
+        self._name = None
+        self.discriminator = None
+
+        self.admitted = admitted
+        if message is not None:
+            self.message = message
+        self.name = name
+
+    @property
+    def admitted(self):
+        """Gets the admitted of this V1PodGroupStatus.  # noqa: E501
+
+        Admitted is true once the PodGroup is admitted by the gang scheduler, thus the replicas of the TFJob can be scheduled together.  # noqa: E501
+
+        :return: The admitted of this V1PodGroupStatus.  # noqa: E501
+        :rtype: bool
+        """
+        return self._admitted
+
+    @admitted.setter
+    def admitted(self, admitted):
+        """Sets the admitted of this V1PodGroupStatus.
+
+        Admitted is true once the PodGroup is admitted by the gang scheduler, thus the replicas of the TFJob can be scheduled together.  # noqa: E501
+
+        :param admitted: The admitted of this V1PodGroupStatus.  # noqa: E501
+        :type: bool
+        """
+        if admitted is None:
+            raise ValueError("Invalid value for `admitted`, must not be `None`")  # noqa: E501
+
+        self._admitted = admitted
+
+    @property
+    def message(self):
+        """Gets the message of this V1PodGroupStatus.  # noqa: E501
+
+        Message tells why the PodGroup is not admitted yet, if the gang scheduler reports it.  # noqa: E501
+
+        :return: The message of this V1PodGroupStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._message
+
+    @message.setter
+    def message(self, message):
+        """Sets the message of this V1PodGroupStatus.
+
+        Message tells why the PodGroup is not admitted yet, if the gang scheduler reports it.  # noqa: E501
+
+        :param message: The message of this V1PodGroupStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._message = message
+
+    @property
+    def name(self):
+        """Gets the name of this V1PodGroupStatus.  # noqa: E501
+
+        Name is the name of the PodGroup.  # noqa: E501
+
+        :return: The name of this V1PodGroupStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1PodGroupStatus.
+
+        Name is the name of the PodGroup.  # noqa: E501
+
+        :param name: The name of this V1PodGroupStatus.  # noqa: E501
+        :type: str
+        """
+        if name is None:
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1PodGroupStatus, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1PodGroupStatus):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
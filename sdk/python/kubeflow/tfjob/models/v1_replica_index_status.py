@@ -0,0 +1,470 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kubeflow.tfjob.models.v1_time import V1Time  # noqa: F401,E501
+
+
+class V1ReplicaIndexStatus(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'container_restarts': 'int',
+        'disruptions': 'int',
+        'index': 'int',
+        'last_exit_code': 'int',
+        'last_restart_time': 'V1Time',
+        'memory_limit': 'str',
+        'memory_request': 'str',
+        'node_name': 'str',
+        'phase': 'str',
+        'pod_name': 'str',
+        'restarts': 'int',
+        'termination_reason': 'str',
+        'waiting_reason': 'str'
+    }
+
+    attribute_map = {
+        'container_restarts': 'containerRestarts',
+        'disruptions': 'disruptions',
+        'index': 'index',
+        'last_exit_code': 'lastExitCode',
+        'last_restart_time': 'lastRestartTime',
+        'memory_limit': 'memoryLimit',
+        'memory_request': 'memoryRequest',
+        'node_name': 'nodeName',
+        'phase': 'phase',
+        'pod_name': 'podName',
+        'restarts': 'restarts',
+        'termination_reason': 'terminationReason',
+        'waiting_reason': 'waitingReason'
+    }
+
+    def __init__(self, container_restarts=None, disruptions=None, index=None, last_exit_code=None, last_restart_time=None, memory_limit=None, memory_request=None, node_name=None, phase=None, pod_name=None, restarts=None, termination_reason=None, waiting_reason=None):  # noqa: E501
+        """V1ReplicaIndexStatus - a model defined in Swagger"""  # noqa: E501
+
+        self._container_restarts = None
+        self._disruptions = None
+        self._index = None
+        self._last_exit_code = None
+        self._last_restart_time = None
+        self._memory_limit = None
+        self._memory_request = None
+        self._node_name = None
+        self._phase = None
+        self._pod_name = None
+        self._restarts = None
+        self._termination_reason = None
+        self._waiting_reason = None
+        self.discriminator = None
+
+        if container_restarts is not None:
+            self.container_restarts = container_restarts
+        if disruptions is not None:
+            self.disruptions = disruptions
+        self.index = index
+        if last_exit_code is not None:
+            self.last_exit_code = last_exit_code
+        if last_restart_time is not None:
+            self.last_restart_time = last_restart_time
+        if memory_limit is not None:
+            self.memory_limit = memory_limit
+        if memory_request is not None:
+            self.memory_request = memory_request
+        if node_name is not None:
+            self.node_name = node_name
+        if phase is not None:
+            self.phase = phase
+        if pod_name is not None:
+            self.pod_name = pod_name
+        if restarts is not None:
+            self.restarts = restarts
+        if termination_reason is not None:
+            self.termination_reason = termination_reason
+        if waiting_reason is not None:
+            self.waiting_reason = waiting_reason
+
+    @property
+    def container_restarts(self):
+        """Gets the container_restarts of this V1ReplicaIndexStatus.  # noqa: E501
+
+        ContainerRestarts is the number of times the TensorFlow container of the current pod has been restarted by the kubelet.  # noqa: E501
+
+        :return: The container_restarts of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._container_restarts
+
+    @container_restarts.setter
+    def container_restarts(self, container_restarts):
+        """Sets the container_restarts of this V1ReplicaIndexStatus.
+
+        ContainerRestarts is the number of times the TensorFlow container of the current pod has been restarted by the kubelet.  # noqa: E501
+
+        :param container_restarts: The container_restarts of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._container_restarts = container_restarts
+
+    @property
+    def disruptions(self):
+        """Gets the disruptions of this V1ReplicaIndexStatus.  # noqa: E501
+
+        Disruptions is the number of times the replica has been recreated because its pod was evicted, preempted or lost with its node. They are not counted in Restarts.  # noqa: E501
+
+        :return: The disruptions of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._disruptions
+
+    @disruptions.setter
+    def disruptions(self, disruptions):
+        """Sets the disruptions of this V1ReplicaIndexStatus.
+
+        Disruptions is the number of times the replica has been recreated because its pod was evicted, preempted or lost with its node. They are not counted in Restarts.  # noqa: E501
+
+        :param disruptions: The disruptions of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._disruptions = disruptions
+
+    @property
+    def index(self):
+        """Gets the index of this V1ReplicaIndexStatus.  # noqa: E501
+
+        Index is the index of the replica.  # noqa: E501
+
+        :return: The index of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._index
+
+    @index.setter
+    def index(self, index):
+        """Sets the index of this V1ReplicaIndexStatus.
+
+        Index is the index of the replica.  # noqa: E501
+
+        :param index: The index of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: int
+        """
+        if index is None:
+            raise ValueError("Invalid value for `index`, must not be `None`")  # noqa: E501
+
+        self._index = index
+
+    @property
+    def last_exit_code(self):
+        """Gets the last_exit_code of this V1ReplicaIndexStatus.  # noqa: E501
+
+        LastExitCode is the exit code of the last termination of the TensorFlow container of the current pod.  # noqa: E501
+
+        :return: The last_exit_code of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._last_exit_code
+
+    @last_exit_code.setter
+    def last_exit_code(self, last_exit_code):
+        """Sets the last_exit_code of this V1ReplicaIndexStatus.
+
+        LastExitCode is the exit code of the last termination of the TensorFlow container of the current pod.  # noqa: E501
+
+        :param last_exit_code: The last_exit_code of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._last_exit_code = last_exit_code
+
+    @property
+    def last_restart_time(self):
+        """Gets the last_restart_time of this V1ReplicaIndexStatus.  # noqa: E501
+
+        LastRestartTime is the last time the replica was restarted by the operator.  # noqa: E501
+
+        :return: The last_restart_time of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: V1Time
+        """
+        return self._last_restart_time
+
+    @last_restart_time.setter
+    def last_restart_time(self, last_restart_time):
+        """Sets the last_restart_time of this V1ReplicaIndexStatus.
+
+        LastRestartTime is the last time the replica was restarted by the operator.  # noqa: E501
+
+        :param last_restart_time: The last_restart_time of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: V1Time
+        """
+
+        self._last_restart_time = last_restart_time
+
+    @property
+    def memory_limit(self):
+        """Gets the memory_limit of this V1ReplicaIndexStatus.  # noqa: E501
+
+        MemoryLimit is the memory limit of the TensorFlow container of the replica raised by the MemoryEscalationPolicy.  # noqa: E501
+
+        :return: The memory_limit of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._memory_limit
+
+    @memory_limit.setter
+    def memory_limit(self, memory_limit):
+        """Sets the memory_limit of this V1ReplicaIndexStatus.
+
+        MemoryLimit is the memory limit of the TensorFlow container of the replica raised by the MemoryEscalationPolicy.  # noqa: E501
+
+        :param memory_limit: The memory_limit of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._memory_limit = memory_limit
+
+    @property
+    def memory_request(self):
+        """Gets the memory_request of this V1ReplicaIndexStatus.  # noqa: E501
+
+        MemoryRequest is the memory request of the TensorFlow container of the replica raised by the MemoryEscalationPolicy.  # noqa: E501
+
+        :return: The memory_request of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._memory_request
+
+    @memory_request.setter
+    def memory_request(self, memory_request):
+        """Sets the memory_request of this V1ReplicaIndexStatus.
+
+        MemoryRequest is the memory request of the TensorFlow container of the replica raised by the MemoryEscalationPolicy.  # noqa: E501
+
+        :param memory_request: The memory_request of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._memory_request = memory_request
+
+    @property
+    def node_name(self):
+        """Gets the node_name of this V1ReplicaIndexStatus.  # noqa: E501
+
+        NodeName is the node the current pod of the replica is scheduled to.  # noqa: E501
+
+        :return: The node_name of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._node_name
+
+    @node_name.setter
+    def node_name(self, node_name):
+        """Sets the node_name of this V1ReplicaIndexStatus.
+
+        NodeName is the node the current pod of the replica is scheduled to.  # noqa: E501
+
+        :param node_name: The node_name of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._node_name = node_name
+
+    @property
+    def phase(self):
+        """Gets the phase of this V1ReplicaIndexStatus.  # noqa: E501
+
+        Phase is the phase of the current pod of the replica.  # noqa: E501
+
+        :return: The phase of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._phase
+
+    @phase.setter
+    def phase(self, phase):
+        """Sets the phase of this V1ReplicaIndexStatus.
+
+        Phase is the phase of the current pod of the replica.  # noqa: E501
+
+        :param phase: The phase of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._phase = phase
+
+    @property
+    def pod_name(self):
+        """Gets the pod_name of this V1ReplicaIndexStatus.  # noqa: E501
+
+        PodName is the name of the current pod of the replica.  # noqa: E501
+
+        :return: The pod_name of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._pod_name
+
+    @pod_name.setter
+    def pod_name(self, pod_name):
+        """Sets the pod_name of this V1ReplicaIndexStatus.
+
+        PodName is the name of the current pod of the replica.  # noqa: E501
+
+        :param pod_name: The pod_name of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._pod_name = pod_name
+
+    @property
+    def restarts(self):
+        """Gets the restarts of this V1ReplicaIndexStatus.  # noqa: E501
+
+        Restarts is the number of times the replica has been restarted by the operator.  # noqa: E501
+
+        :return: The restarts of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._restarts
+
+    @restarts.setter
+    def restarts(self, restarts):
+        """Sets the restarts of this V1ReplicaIndexStatus.
+
+        Restarts is the number of times the replica has been restarted by the operator.  # noqa: E501
+
+        :param restarts: The restarts of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._restarts = restarts
+
+    @property
+    def termination_reason(self):
+        """Gets the termination_reason of this V1ReplicaIndexStatus.  # noqa: E501
+
+        TerminationReason is the reason of the last termination of the TensorFlow container of the current pod, e.g. \"OOMKilled\".  # noqa: E501
+
+        :return: The termination_reason of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._termination_reason
+
+    @termination_reason.setter
+    def termination_reason(self, termination_reason):
+        """Sets the termination_reason of this V1ReplicaIndexStatus.
+
+        TerminationReason is the reason of the last termination of the TensorFlow container of the current pod, e.g. \"OOMKilled\".  # noqa: E501
+
+        :param termination_reason: The termination_reason of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._termination_reason = termination_reason
+
+    @property
+    def waiting_reason(self):
+        """Gets the waiting_reason of this V1ReplicaIndexStatus.  # noqa: E501
+
+        WaitingReason is the reason why the current pod of the replica is not running, e.g. \"ImagePullBackOff\" or \"Unschedulable\".  # noqa: E501
+
+        :return: The waiting_reason of this V1ReplicaIndexStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._waiting_reason
+
+    @waiting_reason.setter
+    def waiting_reason(self, waiting_reason):
+        """Sets the waiting_reason of this V1ReplicaIndexStatus.
+
+        WaitingReason is the reason why the current pod of the replica is not running, e.g. \"ImagePullBackOff\" or \"Unschedulable\".  # noqa: E501
+
+        :param waiting_reason: The waiting_reason of this V1ReplicaIndexStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._waiting_reason = waiting_reason
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1ReplicaIndexStatus, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1ReplicaIndexStatus):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
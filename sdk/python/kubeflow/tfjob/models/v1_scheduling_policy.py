@@ -0,0 +1,199 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+
+class V1SchedulingPolicy(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'min_available': 'int',
+        'min_resources': 'dict(str, str)',
+        'priority_class': 'str',
+        'queue': 'str'
+    }
+
+    attribute_map = {
+        'min_available': 'minAvailable',
+        'min_resources': 'minResources',
+        'priority_class': 'priorityClass',
+        'queue': 'queue'
+    }
+
+    def __init__(self, min_available=None, min_resources=None, priority_class=None, queue=None):  # noqa: E501
+        """V1SchedulingPolicy - a model defined in Swagger"""  # noqa: E501
+
+        self._min_available = None
+        self._min_resources = None
+        self._priority_class = None
+        self._queue = None
+        self.discriminator = None
+
+        if min_available is not None:
+            self.min_available = min_available
+        if min_resources is not None:
+            self.min_resources = min_resources
+        if priority_class is not None:
+            self.priority_class = priority_class
+        if queue is not None:
+            self.queue = queue
+
+    @property
+    def min_available(self):
+        """Gets the min_available of this V1SchedulingPolicy.  # noqa: E501
+
+        :return: The min_available of this V1SchedulingPolicy.  # noqa: E501
+        :rtype: int
+        """
+        return self._min_available
+
+    @min_available.setter
+    def min_available(self, min_available):
+        """Sets the min_available of this V1SchedulingPolicy.
+
+        :param min_available: The min_available of this V1SchedulingPolicy.  # noqa: E501
+        :type: int
+        """
+
+        self._min_available = min_available
+
+    @property
+    def min_resources(self):
+        """Gets the min_resources of this V1SchedulingPolicy.  # noqa: E501
+
+        :return: The min_resources of this V1SchedulingPolicy.  # noqa: E501
+        :rtype: dict(str, str)
+        """
+        return self._min_resources
+
+    @min_resources.setter
+    def min_resources(self, min_resources):
+        """Sets the min_resources of this V1SchedulingPolicy.
+
+        :param min_resources: The min_resources of this V1SchedulingPolicy.  # noqa: E501
+        :type: dict(str, str)
+        """
+
+        self._min_resources = min_resources
+
+    @property
+    def priority_class(self):
+        """Gets the priority_class of this V1SchedulingPolicy.  # noqa: E501
+
+        :return: The priority_class of this V1SchedulingPolicy.  # noqa: E501
+        :rtype: str
+        """
+        return self._priority_class
+
+    @priority_class.setter
+    def priority_class(self, priority_class):
+        """Sets the priority_class of this V1SchedulingPolicy.
+
+        :param priority_class: The priority_class of this V1SchedulingPolicy.  # noqa: E501
+        :type: str
+        """
+
+        self._priority_class = priority_class
+
+    @property
+    def queue(self):
+        """Gets the queue of this V1SchedulingPolicy.  # noqa: E501
+
+        :return: The queue of this V1SchedulingPolicy.  # noqa: E501
+        :rtype: str
+        """
+        return self._queue
+
+    @queue.setter
+    def queue(self, queue):
+        """Sets the queue of this V1SchedulingPolicy.
+
+        :param queue: The queue of this V1SchedulingPolicy.  # noqa: E501
+        :type: str
+        """
+
+        self._queue = queue
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1SchedulingPolicy, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1SchedulingPolicy):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other
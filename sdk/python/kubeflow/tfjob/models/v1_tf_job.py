@@ -31,8 +31,8 @@ import re  # noqa: F401
 import six
 
 from kubernetes.client import V1ObjectMeta  # noqa: F401,E501
-from kubeflow.tfjob.models.v1_job_status import V1JobStatus  # noqa: F401,E501
 from kubeflow.tfjob.models.v1_tf_job_spec import V1TFJobSpec  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_tf_job_status import V1TFJobStatus  # noqa: F401,E501
 
 
 class V1TFJob(object):
@@ -53,7 +53,7 @@ class V1TFJob(object):
         'kind': 'str',
         'metadata': 'V1ObjectMeta',
         'spec': 'V1TFJobSpec',
-        'status': 'V1JobStatus'
+        'status': 'V1TFJobStatus'
     }
 
     attribute_map = {
@@ -89,7 +89,7 @@ class V1TFJob(object):
     def api_version(self):
         """Gets the api_version of this V1TFJob.  # noqa: E501
 
-        APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources  # noqa: E501
+        APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources  # noqa: E501
 
         :return: The api_version of this V1TFJob.  # noqa: E501
         :rtype: str
@@ -100,7 +100,7 @@ class V1TFJob(object):
     def api_version(self, api_version):
         """Sets the api_version of this V1TFJob.
 
-        APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources  # noqa: E501
+        APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources  # noqa: E501
 
         :param api_version: The api_version of this V1TFJob.  # noqa: E501
         :type: str
@@ -112,7 +112,7 @@ class V1TFJob(object):
     def kind(self):
         """Gets the kind of this V1TFJob.  # noqa: E501
 
-        Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds  # noqa: E501
+        Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds  # noqa: E501
 
         :return: The kind of this V1TFJob.  # noqa: E501
         :rtype: str
@@ -123,7 +123,7 @@ class V1TFJob(object):
     def kind(self, kind):
         """Sets the kind of this V1TFJob.
 
-        Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds  # noqa: E501
+        Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds  # noqa: E501
 
         :param kind: The kind of this V1TFJob.  # noqa: E501
         :type: str
@@ -181,10 +181,10 @@ class V1TFJob(object):
     def status(self):
         """Gets the status of this V1TFJob.  # noqa: E501
 
-        Most recently observed status of the TFJob. Read-only (modified by the system).  # noqa: E501
+        Most recently observed status of the TFJob. Populated by the system. Read-only.  # noqa: E501
 
         :return: The status of this V1TFJob.  # noqa: E501
-        :rtype: V1JobStatus
+        :rtype: V1TFJobStatus
         """
         return self._status
 
@@ -192,10 +192,10 @@ class V1TFJob(object):
     def status(self, status):
         """Sets the status of this V1TFJob.
 
-        Most recently observed status of the TFJob. Read-only (modified by the system).  # noqa: E501
+        Most recently observed status of the TFJob. Populated by the system. Read-only.  # noqa: E501
 
         :param status: The status of this V1TFJob.  # noqa: E501
-        :type: V1JobStatus
+        :type: V1TFJobStatus
         """
 
         self._status = status
@@ -30,7 +30,14 @@ import re  # noqa: F401
 
 import six
 
+from kubeflow.tfjob.models.v1_elastic_policy import V1ElasticPolicy  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_exit_code_policy import V1ExitCodePolicy  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_failure_policy import V1FailurePolicy  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_memory_escalation_policy import V1MemoryEscalationPolicy  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_pending_timeout_policy import V1PendingTimeoutPolicy  # noqa: F401,E501
 from kubeflow.tfjob.models.v1_replica_spec import V1ReplicaSpec  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_scheduling_policy import V1SchedulingPolicy  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_startup_policy import V1StartupPolicy  # noqa: F401,E501
 
 
 class V1TFJobSpec(object):
@@ -50,6 +57,24 @@ class V1TFJobSpec(object):
         'active_deadline_seconds': 'int',
         'backoff_limit': 'int',
         'clean_pod_policy': 'str',
+        'cluster_spec_membership': 'dict(str, bool)',
+        'cluster_spec_mode': 'str',
+        'container_name': 'str',
+        'elastic_policy': 'V1ElasticPolicy',
+        'enable_dynamic_worker': 'bool',
+        'exit_code_policies': 'dict(str, V1ExitCodePolicy)',
+        'failure_policies': 'dict(str, V1FailurePolicy)',
+        'memory_escalation_policy': 'V1MemoryEscalationPolicy',
+        'min_members': 'dict(str, int)',
+        'pending_timeout_policy': 'V1PendingTimeoutPolicy',
+        'port_name': 'str',
+        'restart_scope': 'str',
+        'scheduling_policy': 'V1SchedulingPolicy',
+        'startup_policy': 'V1StartupPolicy',
+        'success_policy': 'str',
+        'success_threshold': 'object',
+        'suspend': 'bool',
+        'tf_config_delivery': 'str',
         'tf_replica_specs': 'dict(str, V1ReplicaSpec)',
         'ttl_seconds_after_finished': 'int'
     }
@@ -58,16 +83,52 @@ class V1TFJobSpec(object):
         'active_deadline_seconds': 'activeDeadlineSeconds',
         'backoff_limit': 'backoffLimit',
         'clean_pod_policy': 'cleanPodPolicy',
+        'cluster_spec_membership': 'clusterSpecMembership',
+        'cluster_spec_mode': 'clusterSpecMode',
+        'container_name': 'containerName',
+        'elastic_policy': 'elasticPolicy',
+        'enable_dynamic_worker': 'enableDynamicWorker',
+        'exit_code_policies': 'exitCodePolicies',
+        'failure_policies': 'failurePolicies',
+        'memory_escalation_policy': 'memoryEscalationPolicy',
+        'min_members': 'minMembers',
+        'pending_timeout_policy': 'pendingTimeoutPolicy',
+        'port_name': 'portName',
+        'restart_scope': 'restartScope',
+        'scheduling_policy': 'schedulingPolicy',
+        'startup_policy': 'startupPolicy',
+        'success_policy': 'successPolicy',
+        'success_threshold': 'successThreshold',
+        'suspend': 'suspend',
+        'tf_config_delivery': 'tfConfigDelivery',
         'tf_replica_specs': 'tfReplicaSpecs',
         'ttl_seconds_after_finished': 'ttlSecondsAfterFinished'
     }
 
-    def __init__(self, active_deadline_seconds=None, backoff_limit=None, clean_pod_policy=None, tf_replica_specs=None, ttl_seconds_after_finished=None):  # noqa: E501
+    def __init__(self, active_deadline_seconds=None, backoff_limit=None, clean_pod_policy=None, cluster_spec_membership=None, cluster_spec_mode=None, container_name=None, elastic_policy=None, enable_dynamic_worker=None, exit_code_policies=None, failure_policies=None, memory_escalation_policy=None, min_members=None, pending_timeout_policy=None, port_name=None, restart_scope=None, scheduling_policy=None, startup_policy=None, success_policy=None, success_threshold=None, suspend=None, tf_config_delivery=None, tf_replica_specs=None, ttl_seconds_after_finished=None):  # noqa: E501
         """V1TFJobSpec - a model defined in Swagger"""  # noqa: E501
 
         self._active_deadline_seconds = None
         self._backoff_limit = None
         self._clean_pod_policy = None
+        self._cluster_spec_membership = None
+        self._cluster_spec_mode = None
+        self._container_name = None
+        self._elastic_policy = None
+        self._enable_dynamic_worker = None
+        self._exit_code_policies = None
+        self._failure_policies = None
+        self._memory_escalation_policy = None
+        self._min_members = None
+        self._pending_timeout_policy = None
+        self._port_name = None
+        self._restart_scope = None
+        self._scheduling_policy = None
+        self._startup_policy = None
+        self._success_policy = None
+        self._success_threshold = None
+        self._suspend = None
+        self._tf_config_delivery = None
         self._tf_replica_specs = None
         self._ttl_seconds_after_finished = None
         self.discriminator = None
@@ -78,6 +139,42 @@ class V1TFJobSpec(object):
             self.backoff_limit = backoff_limit
         if clean_pod_policy is not None:
             self.clean_pod_policy = clean_pod_policy
+        if cluster_spec_membership is not None:
+            self.cluster_spec_membership = cluster_spec_membership
+        if cluster_spec_mode is not None:
+            self.cluster_spec_mode = cluster_spec_mode
+        if container_name is not None:
+            self.container_name = container_name
+        if elastic_policy is not None:
+            self.elastic_policy = elastic_policy
+        if enable_dynamic_worker is not None:
+            self.enable_dynamic_worker = enable_dynamic_worker
+        if exit_code_policies is not None:
+            self.exit_code_policies = exit_code_policies
+        if failure_policies is not None:
+            self.failure_policies = failure_policies
+        if memory_escalation_policy is not None:
+            self.memory_escalation_policy = memory_escalation_policy
+        if min_members is not None:
+            self.min_members = min_members
+        if pending_timeout_policy is not None:
+            self.pending_timeout_policy = pending_timeout_policy
+        if port_name is not None:
+            self.port_name = port_name
+        if restart_scope is not None:
+            self.restart_scope = restart_scope
+        if scheduling_policy is not None:
+            self.scheduling_policy = scheduling_policy
+        if startup_policy is not None:
+            self.startup_policy = startup_policy
+        if success_policy is not None:
+            self.success_policy = success_policy
+        if success_threshold is not None:
+            self.success_threshold = success_threshold
+        if suspend is not None:
+            self.suspend = suspend
+        if tf_config_delivery is not None:
+            self.tf_config_delivery = tf_config_delivery
         self.tf_replica_specs = tf_replica_specs
         if ttl_seconds_after_finished is not None:
             self.ttl_seconds_after_finished = ttl_seconds_after_finished
@@ -86,7 +183,7 @@ class V1TFJobSpec(object):
     def active_deadline_seconds(self):
         """Gets the active_deadline_seconds of this V1TFJobSpec.  # noqa: E501
 
-        Specifies the duration (in seconds) since startTime during which the job can remain active before it is terminated. Must be a positive integer. This setting applies only to pods where restartPolicy is OnFailure or Always.  # noqa: E501
+        Specifies the duration in seconds relative to the startTime that the job may be active before the system tries to terminate it; value must be positive integer.  # noqa: E501
 
         :return: The active_deadline_seconds of this V1TFJobSpec.  # noqa: E501
         :rtype: int
@@ -97,7 +194,7 @@ class V1TFJobSpec(object):
     def active_deadline_seconds(self, active_deadline_seconds):
         """Sets the active_deadline_seconds of this V1TFJobSpec.
 
-        Specifies the duration (in seconds) since startTime during which the job can remain active before it is terminated. Must be a positive integer. This setting applies only to pods where restartPolicy is OnFailure or Always.  # noqa: E501
+        Specifies the duration in seconds relative to the startTime that the job may be active before the system tries to terminate it; value must be positive integer.  # noqa: E501
 
         :param active_deadline_seconds: The active_deadline_seconds of this V1TFJobSpec.  # noqa: E501
         :type: int
@@ -109,7 +206,7 @@ class V1TFJobSpec(object):
     def backoff_limit(self):
         """Gets the backoff_limit of this V1TFJobSpec.  # noqa: E501
 
-        Number of retries before marking this job as failed.  # noqa: E501
+        Optional number of retries before marking this job failed.  # noqa: E501
 
         :return: The backoff_limit of this V1TFJobSpec.  # noqa: E501
         :rtype: int
@@ -120,7 +217,7 @@ class V1TFJobSpec(object):
     def backoff_limit(self, backoff_limit):
         """Sets the backoff_limit of this V1TFJobSpec.
 
-        Number of retries before marking this job as failed.  # noqa: E501
+        Optional number of retries before marking this job failed.  # noqa: E501
 
         :param backoff_limit: The backoff_limit of this V1TFJobSpec.  # noqa: E501
         :type: int
@@ -132,7 +229,7 @@ class V1TFJobSpec(object):
     def clean_pod_policy(self):
         """Gets the clean_pod_policy of this V1TFJobSpec.  # noqa: E501
 
-        Defines the policy for cleaning up pods after the TFJob completes. Defaults to Running.  # noqa: E501
+        CleanPodPolicy defines the policy to kill pods after the job completes. Default to Running.  # noqa: E501
 
         :return: The clean_pod_policy of this V1TFJobSpec.  # noqa: E501
         :rtype: str
@@ -143,7 +240,7 @@ class V1TFJobSpec(object):
     def clean_pod_policy(self, clean_pod_policy):
         """Sets the clean_pod_policy of this V1TFJobSpec.
 
-        Defines the policy for cleaning up pods after the TFJob completes. Defaults to Running.  # noqa: E501
+        CleanPodPolicy defines the policy to kill pods after the job completes. Default to Running.  # noqa: E501
 
         :param clean_pod_policy: The clean_pod_policy of this V1TFJobSpec.  # noqa: E501
         :type: str
@@ -151,6 +248,420 @@ class V1TFJobSpec(object):
 
         self._clean_pod_policy = clean_pod_policy
 
+    @property
+    def cluster_spec_membership(self):
+        """Gets the cluster_spec_membership of this V1TFJobSpec.  # noqa: E501
+
+        A map of TFReplicaType (type) to whether the replicas of the type are members of the cluster spec in TF_CONFIG. By default all replica types except Evaluator are members. Replicas which are not members still receive TF_CONFIG with their own task, e.g.   {     \"Evaluator\": true,   }  # noqa: E501
+
+        :return: The cluster_spec_membership of this V1TFJobSpec.  # noqa: E501
+        :rtype: dict(str, bool)
+        """
+        return self._cluster_spec_membership
+
+    @cluster_spec_membership.setter
+    def cluster_spec_membership(self, cluster_spec_membership):
+        """Sets the cluster_spec_membership of this V1TFJobSpec.
+
+        A map of TFReplicaType (type) to whether the replicas of the type are members of the cluster spec in TF_CONFIG. By default all replica types except Evaluator are members. Replicas which are not members still receive TF_CONFIG with their own task, e.g.   {     \"Evaluator\": true,   }  # noqa: E501
+
+        :param cluster_spec_membership: The cluster_spec_membership of this V1TFJobSpec.  # noqa: E501
+        :type: dict(str, bool)
+        """
+
+        self._cluster_spec_membership = cluster_spec_membership
+
+    @property
+    def cluster_spec_mode(self):
+        """Gets the cluster_spec_mode of this V1TFJobSpec.  # noqa: E501
+
+        ClusterSpecMode specifies how the replicas are addressed in TF_CONFIG. One of \"Service\", \"PodIP\" or \"Hostname\". Default to \"Service\".  # noqa: E501
+
+        :return: The cluster_spec_mode of this V1TFJobSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._cluster_spec_mode
+
+    @cluster_spec_mode.setter
+    def cluster_spec_mode(self, cluster_spec_mode):
+        """Sets the cluster_spec_mode of this V1TFJobSpec.
+
+        ClusterSpecMode specifies how the replicas are addressed in TF_CONFIG. One of \"Service\", \"PodIP\" or \"Hostname\". Default to \"Service\".  # noqa: E501
+
+        :param cluster_spec_mode: The cluster_spec_mode of this V1TFJobSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._cluster_spec_mode = cluster_spec_mode
+
+    @property
+    def container_name(self):
+        """Gets the container_name of this V1TFJobSpec.  # noqa: E501
+
+        ContainerName is the name of the TensorFlow container of the replicas, which receives TF_CONFIG and decides the exit code of the replicas. The \"kubeflow.org/container-name\" annotation of the pod template of a replica type takes precedence over it. Default to \"tensorflow\".  # noqa: E501
+
+        :return: The container_name of this V1TFJobSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._container_name
+
+    @container_name.setter
+    def container_name(self, container_name):
+        """Sets the container_name of this V1TFJobSpec.
+
+        ContainerName is the name of the TensorFlow container of the replicas, which receives TF_CONFIG and decides the exit code of the replicas. The \"kubeflow.org/container-name\" annotation of the pod template of a replica type takes precedence over it. Default to \"tensorflow\".  # noqa: E501
+
+        :param container_name: The container_name of this V1TFJobSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._container_name = container_name
+
+    @property
+    def elastic_policy(self):
+        """Gets the elastic_policy of this V1TFJobSpec.  # noqa: E501
+
+        ElasticPolicy lets the number of Worker replicas change within a range while the TFJob is running, e.g. through the scale subresource. It requires EnableDynamicWorker.  # noqa: E501
+
+        :return: The elastic_policy of this V1TFJobSpec.  # noqa: E501
+        :rtype: V1ElasticPolicy
+        """
+        return self._elastic_policy
+
+    @elastic_policy.setter
+    def elastic_policy(self, elastic_policy):
+        """Sets the elastic_policy of this V1TFJobSpec.
+
+        ElasticPolicy lets the number of Worker replicas change within a range while the TFJob is running, e.g. through the scale subresource. It requires EnableDynamicWorker.  # noqa: E501
+
+        :param elastic_policy: The elastic_policy of this V1TFJobSpec.  # noqa: E501
+        :type: V1ElasticPolicy
+        """
+
+        self._elastic_policy = elastic_policy
+
+    @property
+    def enable_dynamic_worker(self):
+        """Gets the enable_dynamic_worker of this V1TFJobSpec.  # noqa: E501
+
+        A switch to enable dynamic worker  # noqa: E501
+
+        :return: The enable_dynamic_worker of this V1TFJobSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._enable_dynamic_worker
+
+    @enable_dynamic_worker.setter
+    def enable_dynamic_worker(self, enable_dynamic_worker):
+        """Sets the enable_dynamic_worker of this V1TFJobSpec.
+
+        A switch to enable dynamic worker  # noqa: E501
+
+        :param enable_dynamic_worker: The enable_dynamic_worker of this V1TFJobSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._enable_dynamic_worker = enable_dynamic_worker
+
+    @property
+    def exit_code_policies(self):
+        """Gets the exit_code_policies of this V1TFJobSpec.  # noqa: E501
+
+        A map of TFReplicaType (type) to ExitCodePolicy (value). Specifies which exit codes restart the replicas of the type with the \"ExitCode\" restart policy, e.g.   {     \"Worker\": {       \"retryableExitCodes\": [{\"min\": 130, \"max\": 143}],       \"permanentExitCodes\": [{\"min\": 3}],     },   }  # noqa: E501
+
+        :return: The exit_code_policies of this V1TFJobSpec.  # noqa: E501
+        :rtype: dict(str, V1ExitCodePolicy)
+        """
+        return self._exit_code_policies
+
+    @exit_code_policies.setter
+    def exit_code_policies(self, exit_code_policies):
+        """Sets the exit_code_policies of this V1TFJobSpec.
+
+        A map of TFReplicaType (type) to ExitCodePolicy (value). Specifies which exit codes restart the replicas of the type with the \"ExitCode\" restart policy, e.g.   {     \"Worker\": {       \"retryableExitCodes\": [{\"min\": 130, \"max\": 143}],       \"permanentExitCodes\": [{\"min\": 3}],     },   }  # noqa: E501
+
+        :param exit_code_policies: The exit_code_policies of this V1TFJobSpec.  # noqa: E501
+        :type: dict(str, V1ExitCodePolicy)
+        """
+
+        self._exit_code_policies = exit_code_policies
+
+    @property
+    def failure_policies(self):
+        """Gets the failure_policies of this V1TFJobSpec.  # noqa: E501
+
+        A map of TFReplicaType (type) to FailurePolicy (value). Specifies whether failed replicas of the type fail the TFJob, e.g.   {     \"Evaluator\": {\"type\": \"Ignore\"},     \"Worker\": {\"type\": \"Tolerate\", \"maxFailures\": 2},   } The TFJob fails as soon as a replica fails for the types not in the map.  # noqa: E501
+
+        :return: The failure_policies of this V1TFJobSpec.  # noqa: E501
+        :rtype: dict(str, V1FailurePolicy)
+        """
+        return self._failure_policies
+
+    @failure_policies.setter
+    def failure_policies(self, failure_policies):
+        """Sets the failure_policies of this V1TFJobSpec.
+
+        A map of TFReplicaType (type) to FailurePolicy (value). Specifies whether failed replicas of the type fail the TFJob, e.g.   {     \"Evaluator\": {\"type\": \"Ignore\"},     \"Worker\": {\"type\": \"Tolerate\", \"maxFailures\": 2},   } The TFJob fails as soon as a replica fails for the types not in the map.  # noqa: E501
+
+        :param failure_policies: The failure_policies of this V1TFJobSpec.  # noqa: E501
+        :type: dict(str, V1FailurePolicy)
+        """
+
+        self._failure_policies = failure_policies
+
+    @property
+    def memory_escalation_policy(self):
+        """Gets the memory_escalation_policy of this V1TFJobSpec.  # noqa: E501
+
+        MemoryEscalationPolicy recreates the replicas which are OOMKilled with more memory. The raised memory is recorded in the ReplicaIndexStatuses. Default to keep the memory of the replicas.  # noqa: E501
+
+        :return: The memory_escalation_policy of this V1TFJobSpec.  # noqa: E501
+        :rtype: V1MemoryEscalationPolicy
+        """
+        return self._memory_escalation_policy
+
+    @memory_escalation_policy.setter
+    def memory_escalation_policy(self, memory_escalation_policy):
+        """Sets the memory_escalation_policy of this V1TFJobSpec.
+
+        MemoryEscalationPolicy recreates the replicas which are OOMKilled with more memory. The raised memory is recorded in the ReplicaIndexStatuses. Default to keep the memory of the replicas.  # noqa: E501
+
+        :param memory_escalation_policy: The memory_escalation_policy of this V1TFJobSpec.  # noqa: E501
+        :type: V1MemoryEscalationPolicy
+        """
+
+        self._memory_escalation_policy = memory_escalation_policy
+
+    @property
+    def min_members(self):
+        """Gets the min_members of this V1TFJobSpec.  # noqa: E501
+
+        A map of TFReplicaType (type) to the minimum number of replicas of the type which are gang-scheduled together, e.g. all PS and the Chief but only 2 of the Workers:   {     \"Worker\": 2,   } All replicas of the types not in the map are members, except the Workers of an elastic TFJob, of which the MinReplicas of the ElasticPolicy are members. The PS, Chief, Master and Coordinator replicas are never split. It cannot be used together with SchedulingPolicy.MinAvailable, and the TFJob fails if the gang scheduler of the operator does not support it, e.g. the coscheduling plugin.  # noqa: E501
+
+        :return: The min_members of this V1TFJobSpec.  # noqa: E501
+        :rtype: dict(str, int)
+        """
+        return self._min_members
+
+    @min_members.setter
+    def min_members(self, min_members):
+        """Sets the min_members of this V1TFJobSpec.
+
+        A map of TFReplicaType (type) to the minimum number of replicas of the type which are gang-scheduled together, e.g. all PS and the Chief but only 2 of the Workers:   {     \"Worker\": 2,   } All replicas of the types not in the map are members, except the Workers of an elastic TFJob, of which the MinReplicas of the ElasticPolicy are members. The PS, Chief, Master and Coordinator replicas are never split. It cannot be used together with SchedulingPolicy.MinAvailable, and the TFJob fails if the gang scheduler of the operator does not support it, e.g. the coscheduling plugin.  # noqa: E501
+
+        :param min_members: The min_members of this V1TFJobSpec.  # noqa: E501
+        :type: dict(str, int)
+        """
+
+        self._min_members = min_members
+
+    @property
+    def pending_timeout_policy(self):
+        """Gets the pending_timeout_policy of this V1TFJobSpec.  # noqa: E501
+
+        PendingTimeoutPolicy fails or suspends the TFJob when any of its replicas stays Pending for too long. The reason of the TFJob quotes the reason why the replica is pending, e.g. \"ImagePullBackOff\".  # noqa: E501
+
+        :return: The pending_timeout_policy of this V1TFJobSpec.  # noqa: E501
+        :rtype: V1PendingTimeoutPolicy
+        """
+        return self._pending_timeout_policy
+
+    @pending_timeout_policy.setter
+    def pending_timeout_policy(self, pending_timeout_policy):
+        """Sets the pending_timeout_policy of this V1TFJobSpec.
+
+        PendingTimeoutPolicy fails or suspends the TFJob when any of its replicas stays Pending for too long. The reason of the TFJob quotes the reason why the replica is pending, e.g. \"ImagePullBackOff\".  # noqa: E501
+
+        :param pending_timeout_policy: The pending_timeout_policy of this V1TFJobSpec.  # noqa: E501
+        :type: V1PendingTimeoutPolicy
+        """
+
+        self._pending_timeout_policy = pending_timeout_policy
+
+    @property
+    def port_name(self):
+        """Gets the port_name of this V1TFJobSpec.  # noqa: E501
+
+        PortName is the name of the port of the TensorFlow container which is used in the cluster spec. The \"kubeflow.org/port-name\" annotation of the pod template of a replica type takes precedence over it. Default to \"tfjob-port\".  # noqa: E501
+
+        :return: The port_name of this V1TFJobSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._port_name
+
+    @port_name.setter
+    def port_name(self, port_name):
+        """Sets the port_name of this V1TFJobSpec.
+
+        PortName is the name of the port of the TensorFlow container which is used in the cluster spec. The \"kubeflow.org/port-name\" annotation of the pod template of a replica type takes precedence over it. Default to \"tfjob-port\".  # noqa: E501
+
+        :param port_name: The port_name of this V1TFJobSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._port_name = port_name
+
+    @property
+    def restart_scope(self):
+        """Gets the restart_scope of this V1TFJobSpec.  # noqa: E501
+
+        RestartScope specifies which replicas are restarted when a replica with the ExitCode restart policy fails with a retryable exit code. One of \"Replica\" or \"Job\". Default to \"Replica\".  # noqa: E501
+
+        :return: The restart_scope of this V1TFJobSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._restart_scope
+
+    @restart_scope.setter
+    def restart_scope(self, restart_scope):
+        """Sets the restart_scope of this V1TFJobSpec.
+
+        RestartScope specifies which replicas are restarted when a replica with the ExitCode restart policy fails with a retryable exit code. One of \"Replica\" or \"Job\". Default to \"Replica\".  # noqa: E501
+
+        :param restart_scope: The restart_scope of this V1TFJobSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._restart_scope = restart_scope
+
+    @property
+    def scheduling_policy(self):
+        """Gets the scheduling_policy of this V1TFJobSpec.  # noqa: E501
+
+        SchedulingPolicy defines the policy related to scheduling, e.g. gang-scheduling  # noqa: E501
+
+        :return: The scheduling_policy of this V1TFJobSpec.  # noqa: E501
+        :rtype: V1SchedulingPolicy
+        """
+        return self._scheduling_policy
+
+    @scheduling_policy.setter
+    def scheduling_policy(self, scheduling_policy):
+        """Sets the scheduling_policy of this V1TFJobSpec.
+
+        SchedulingPolicy defines the policy related to scheduling, e.g. gang-scheduling  # noqa: E501
+
+        :param scheduling_policy: The scheduling_policy of this V1TFJobSpec.  # noqa: E501
+        :type: V1SchedulingPolicy
+        """
+
+        self._scheduling_policy = scheduling_policy
+
+    @property
+    def startup_policy(self):
+        """Gets the startup_policy of this V1TFJobSpec.  # noqa: E501
+
+        StartupPolicy specifies the order in which the replicas are created, e.g. to create the Worker replicas once the PS replicas are running. Default to create all replicas at once.  # noqa: E501
+
+        :return: The startup_policy of this V1TFJobSpec.  # noqa: E501
+        :rtype: V1StartupPolicy
+        """
+        return self._startup_policy
+
+    @startup_policy.setter
+    def startup_policy(self, startup_policy):
+        """Sets the startup_policy of this V1TFJobSpec.
+
+        StartupPolicy specifies the order in which the replicas are created, e.g. to create the Worker replicas once the PS replicas are running. Default to create all replicas at once.  # noqa: E501
+
+        :param startup_policy: The startup_policy of this V1TFJobSpec.  # noqa: E501
+        :type: V1StartupPolicy
+        """
+
+        self._startup_policy = startup_policy
+
+    @property
+    def success_policy(self):
+        """Gets the success_policy of this V1TFJobSpec.  # noqa: E501
+
+        SuccessPolicy defines the policy to mark the TFJob as succeeded. Default to \"\", using the default rules.  # noqa: E501
+
+        :return: The success_policy of this V1TFJobSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._success_policy
+
+    @success_policy.setter
+    def success_policy(self, success_policy):
+        """Sets the success_policy of this V1TFJobSpec.
+
+        SuccessPolicy defines the policy to mark the TFJob as succeeded. Default to \"\", using the default rules.  # noqa: E501
+
+        :param success_policy: The success_policy of this V1TFJobSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._success_policy = success_policy
+
+    @property
+    def success_threshold(self):
+        """Gets the success_threshold of this V1TFJobSpec.  # noqa: E501
+
+        SuccessThreshold is the number or percentage of Worker replicas which need to succeed to mark the TFJob as succeeded. A percentage is rounded up. Only used with the \"WorkerThreshold\" success policy.  # noqa: E501
+
+        :return: The success_threshold of this V1TFJobSpec.  # noqa: E501
+        :rtype: object
+        """
+        return self._success_threshold
+
+    @success_threshold.setter
+    def success_threshold(self, success_threshold):
+        """Sets the success_threshold of this V1TFJobSpec.
+
+        SuccessThreshold is the number or percentage of Worker replicas which need to succeed to mark the TFJob as succeeded. A percentage is rounded up. Only used with the \"WorkerThreshold\" success policy.  # noqa: E501
+
+        :param success_threshold: The success_threshold of this V1TFJobSpec.  # noqa: E501
+        :type: object
+        """
+
+        self._success_threshold = success_threshold
+
+    @property
+    def suspend(self):
+        """Gets the suspend of this V1TFJobSpec.  # noqa: E501
+
+        Suspend specifies whether the TFJob is suspended. Suspending a TFJob deletes all of its pods and services, and resuming it recreates the replicas from scratch. The ActiveDeadlineSeconds timer is paused while the TFJob is suspended. Default to false.  # noqa: E501
+
+        :return: The suspend of this V1TFJobSpec.  # noqa: E501
+        :rtype: bool
+        """
+        return self._suspend
+
+    @suspend.setter
+    def suspend(self, suspend):
+        """Sets the suspend of this V1TFJobSpec.
+
+        Suspend specifies whether the TFJob is suspended. Suspending a TFJob deletes all of its pods and services, and resuming it recreates the replicas from scratch. The ActiveDeadlineSeconds timer is paused while the TFJob is suspended. Default to false.  # noqa: E501
+
+        :param suspend: The suspend of this V1TFJobSpec.  # noqa: E501
+        :type: bool
+        """
+
+        self._suspend = suspend
+
+    @property
+    def tf_config_delivery(self):
+        """Gets the tf_config_delivery of this V1TFJobSpec.  # noqa: E501
+
+        TFConfigDelivery specifies how TF_CONFIG is delivered to the replicas. One of \"Env\" or \"ConfigMap\". Default to \"Env\". With \"ConfigMap\", the training code has to merge the cluster spec from the file in TF_CLUSTER_SPEC_FILE into TF_CONFIG itself.  # noqa: E501
+
+        :return: The tf_config_delivery of this V1TFJobSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._tf_config_delivery
+
+    @tf_config_delivery.setter
+    def tf_config_delivery(self, tf_config_delivery):
+        """Sets the tf_config_delivery of this V1TFJobSpec.
+
+        TFConfigDelivery specifies how TF_CONFIG is delivered to the replicas. One of \"Env\" or \"ConfigMap\". Default to \"Env\". With \"ConfigMap\", the training code has to merge the cluster spec from the file in TF_CLUSTER_SPEC_FILE into TF_CONFIG itself.  # noqa: E501
+
+        :param tf_config_delivery: The tf_config_delivery of this V1TFJobSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._tf_config_delivery = tf_config_delivery
+
     @property
     def tf_replica_specs(self):
         """Gets the tf_replica_specs of this V1TFJobSpec.  # noqa: E501
@@ -180,7 +691,7 @@ class V1TFJobSpec(object):
     def ttl_seconds_after_finished(self):
         """Gets the ttl_seconds_after_finished of this V1TFJobSpec.  # noqa: E501
 
-        Defines the TTL for cleaning up finished TFJobs (temporary before kubernetes adds the cleanup controller). It may take extra ReconcilePeriod seconds for the cleanup, since reconcile gets called periodically. Defaults to infinite.  # noqa: E501
+        TTLSecondsAfterFinished is the TTL to clean up jobs. It may take extra ReconcilePeriod seconds for the cleanup, since reconcile gets called periodically. Default to infinite.  # noqa: E501
 
         :return: The ttl_seconds_after_finished of this V1TFJobSpec.  # noqa: E501
         :rtype: int
@@ -191,7 +702,7 @@ class V1TFJobSpec(object):
     def ttl_seconds_after_finished(self, ttl_seconds_after_finished):
         """Sets the ttl_seconds_after_finished of this V1TFJobSpec.
 
-        Defines the TTL for cleaning up finished TFJobs (temporary before kubernetes adds the cleanup controller). It may take extra ReconcilePeriod seconds for the cleanup, since reconcile gets called periodically. Defaults to infinite.  # noqa: E501
+        TTLSecondsAfterFinished is the TTL to clean up jobs. It may take extra ReconcilePeriod seconds for the cleanup, since reconcile gets called periodically. Default to infinite.  # noqa: E501
 
         :param ttl_seconds_after_finished: The ttl_seconds_after_finished of this V1TFJobSpec.  # noqa: E501
         :type: int
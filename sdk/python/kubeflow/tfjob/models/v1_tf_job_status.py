@@ -0,0 +1,419 @@
+# Copyright 2019 kubeflow.org.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    tfjob
+
+    Python SDK for TF-Operator  # noqa: E501
+
+    OpenAPI spec version: v0.1
+    
+    Generated by: https://github.com/swagger-api/swagger-codegen.git
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kubernetes.client import V1JobCondition  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_pod_group_status import V1PodGroupStatus  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_replica_index_status import V1ReplicaIndexStatus  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_replica_status import V1ReplicaStatus  # noqa: F401,E501
+from kubeflow.tfjob.models.v1_time import V1Time  # noqa: F401,E501
+
+
+class V1TFJobStatus(object):
+    """NOTE: This class is auto generated by the swagger code generator program.
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      swagger_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    swagger_types = {
+        'completion_time': 'V1Time',
+        'conditions': 'list[V1JobCondition]',
+        'last_reconcile_time': 'V1Time',
+        'pod_group_status': 'V1PodGroupStatus',
+        'replica_index_statuses': 'dict(str, list[V1ReplicaIndexStatus])',
+        'replica_statuses': 'dict(str, V1ReplicaStatus)',
+        'restart_attempt': 'int',
+        'selector': 'str',
+        'start_time': 'V1Time',
+        'suspended_generation': 'int',
+        'waiting_for': 'list[str]'
+    }
+
+    attribute_map = {
+        'completion_time': 'completionTime',
+        'conditions': 'conditions',
+        'last_reconcile_time': 'lastReconcileTime',
+        'pod_group_status': 'podGroupStatus',
+        'replica_index_statuses': 'replicaIndexStatuses',
+        'replica_statuses': 'replicaStatuses',
+        'restart_attempt': 'restartAttempt',
+        'selector': 'selector',
+        'start_time': 'startTime',
+        'suspended_generation': 'suspendedGeneration',
+        'waiting_for': 'waitingFor'
+    }
+
+    def __init__(self, completion_time=None, conditions=None, last_reconcile_time=None, pod_group_status=None, replica_index_statuses=None, replica_statuses=None, restart_attempt=None, selector=None, start_time=None, suspended_generation=None, waiting_for=None):  # noqa: E501
+        """V1TFJobStatus - a model defined in Swagger"""  # noqa: E501
+
+        self._completion_time = None
+        self._conditions = None
+        self._last_reconcile_time = None
+        self._pod_group_status = None
+        self._replica_index_statuses = None
+        self._replica_statuses = None
+        self._restart_attempt = None
+        self._selector = None
+        self._start_time = None
+        self._suspended_generation = None
+        self._waiting_for = None
+        self.discriminator = None
+
+        if completion_time is not None:
+            self.completion_time = completion_time
+        self.conditions = conditions
+        if last_reconcile_time is not None:
+            self.last_reconcile_time = last_reconcile_time
+        if pod_group_status is not None:
+            self.pod_group_status = pod_group_status
+        if replica_index_statuses is not None:
+            self.replica_index_statuses = replica_index_statuses
+        self.replica_statuses = replica_statuses
+        if restart_attempt is not None:
+            self.restart_attempt = restart_attempt
+        if selector is not None:
+            self.selector = selector
+        if start_time is not None:
+            self.start_time = start_time
+        if suspended_generation is not None:
+            self.suspended_generation = suspended_generation
+        if waiting_for is not None:
+            self.waiting_for = waiting_for
+
+    @property
+    def completion_time(self):
+        """Gets the completion_time of this V1TFJobStatus.  # noqa: E501
+
+        Represents time when the job was completed. It is not guaranteed to be set in happens-before order across separate operations. It is represented in RFC3339 form and is in UTC.  # noqa: E501
+
+        :return: The completion_time of this V1TFJobStatus.  # noqa: E501
+        :rtype: V1Time
+        """
+        return self._completion_time
+
+    @completion_time.setter
+    def completion_time(self, completion_time):
+        """Sets the completion_time of this V1TFJobStatus.
+
+        Represents time when the job was completed. It is not guaranteed to be set in happens-before order across separate operations. It is represented in RFC3339 form and is in UTC.  # noqa: E501
+
+        :param completion_time: The completion_time of this V1TFJobStatus.  # noqa: E501
+        :type: V1Time
+        """
+
+        self._completion_time = completion_time
+
+    @property
+    def conditions(self):
+        """Gets the conditions of this V1TFJobStatus.  # noqa: E501
+
+        Conditions is an array of current observed job conditions.  # noqa: E501
+
+        :return: The conditions of this V1TFJobStatus.  # noqa: E501
+        :rtype: list[V1JobCondition]
+        """
+        return self._conditions
+
+    @conditions.setter
+    def conditions(self, conditions):
+        """Sets the conditions of this V1TFJobStatus.
+
+        Conditions is an array of current observed job conditions.  # noqa: E501
+
+        :param conditions: The conditions of this V1TFJobStatus.  # noqa: E501
+        :type: list[V1JobCondition]
+        """
+        if conditions is None:
+            raise ValueError("Invalid value for `conditions`, must not be `None`")  # noqa: E501
+
+        self._conditions = conditions
+
+    @property
+    def last_reconcile_time(self):
+        """Gets the last_reconcile_time of this V1TFJobStatus.  # noqa: E501
+
+        Represents last time when the job was reconciled. It is not guaranteed to be set in happens-before order across separate operations. It is represented in RFC3339 form and is in UTC.  # noqa: E501
+
+        :return: The last_reconcile_time of this V1TFJobStatus.  # noqa: E501
+        :rtype: V1Time
+        """
+        return self._last_reconcile_time
+
+    @last_reconcile_time.setter
+    def last_reconcile_time(self, last_reconcile_time):
+        """Sets the last_reconcile_time of this V1TFJobStatus.
+
+        Represents last time when the job was reconciled. It is not guaranteed to be set in happens-before order across separate operations. It is represented in RFC3339 form and is in UTC.  # noqa: E501
+
+        :param last_reconcile_time: The last_reconcile_time of this V1TFJobStatus.  # noqa: E501
+        :type: V1Time
+        """
+
+        self._last_reconcile_time = last_reconcile_time
+
+    @property
+    def pod_group_status(self):
+        """Gets the pod_group_status of this V1TFJobStatus.  # noqa: E501
+
+        PodGroupStatus is the status of the PodGroup of the TFJob, which is set when the TFJob is gang-scheduled.  # noqa: E501
+
+        :return: The pod_group_status of this V1TFJobStatus.  # noqa: E501
+        :rtype: V1PodGroupStatus
+        """
+        return self._pod_group_status
+
+    @pod_group_status.setter
+    def pod_group_status(self, pod_group_status):
+        """Sets the pod_group_status of this V1TFJobStatus.
+
+        PodGroupStatus is the status of the PodGroup of the TFJob, which is set when the TFJob is gang-scheduled.  # noqa: E501
+
+        :param pod_group_status: The pod_group_status of this V1TFJobStatus.  # noqa: E501
+        :type: V1PodGroupStatus
+        """
+
+        self._pod_group_status = pod_group_status
+
+    @property
+    def replica_index_statuses(self):
+        """Gets the replica_index_statuses of this V1TFJobStatus.  # noqa: E501
+
+        ReplicaIndexStatuses is the status of each replica index, keyed by the replica type, e.g. {\"Worker\": [{\"index\": 0, \"podName\": \"foo-worker-0\", \"restarts\": 2}]}.  # noqa: E501
+
+        :return: The replica_index_statuses of this V1TFJobStatus.  # noqa: E501
+        :rtype: dict(str, list[V1ReplicaIndexStatus])
+        """
+        return self._replica_index_statuses
+
+    @replica_index_statuses.setter
+    def replica_index_statuses(self, replica_index_statuses):
+        """Sets the replica_index_statuses of this V1TFJobStatus.
+
+        ReplicaIndexStatuses is the status of each replica index, keyed by the replica type, e.g. {\"Worker\": [{\"index\": 0, \"podName\": \"foo-worker-0\", \"restarts\": 2}]}.  # noqa: E501
+
+        :param replica_index_statuses: The replica_index_statuses of this V1TFJobStatus.  # noqa: E501
+        :type: dict(str, list[V1ReplicaIndexStatus])
+        """
+
+        self._replica_index_statuses = replica_index_statuses
+
+    @property
+    def replica_statuses(self):
+        """Gets the replica_statuses of this V1TFJobStatus.  # noqa: E501
+
+        ReplicaStatuses is map of ReplicaType and ReplicaStatus, specifies the status of each replica.  # noqa: E501
+
+        :return: The replica_statuses of this V1TFJobStatus.  # noqa: E501
+        :rtype: dict(str, V1ReplicaStatus)
+        """
+        return self._replica_statuses
+
+    @replica_statuses.setter
+    def replica_statuses(self, replica_statuses):
+        """Sets the replica_statuses of this V1TFJobStatus.
+
+        ReplicaStatuses is map of ReplicaType and ReplicaStatus, specifies the status of each replica.  # noqa: E501
+
+        :param replica_statuses: The replica_statuses of this V1TFJobStatus.  # noqa: E501
+        :type: dict(str, V1ReplicaStatus)
+        """
+        if replica_statuses is None:
+            raise ValueError("Invalid value for `replica_statuses`, must not be `None`")  # noqa: E501
+
+        self._replica_statuses = replica_statuses
+
+    @property
+    def restart_attempt(self):
+        """Gets the restart_attempt of this V1TFJobStatus.  # noqa: E501
+
+        RestartAttempt is the number of times all replicas of the TFJob have been restarted with the \"Job\" restart scope. The pods of the current attempt are labeled with it.  # noqa: E501
+
+        :return: The restart_attempt of this V1TFJobStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._restart_attempt
+
+    @restart_attempt.setter
+    def restart_attempt(self, restart_attempt):
+        """Sets the restart_attempt of this V1TFJobStatus.
+
+        RestartAttempt is the number of times all replicas of the TFJob have been restarted with the \"Job\" restart scope. The pods of the current attempt are labeled with it.  # noqa: E501
+
+        :param restart_attempt: The restart_attempt of this V1TFJobStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._restart_attempt = restart_attempt
+
+    @property
+    def selector(self):
+        """Gets the selector of this V1TFJobStatus.  # noqa: E501
+
+        Selector is the label selector of the Worker pods of the TFJob, which is the selector of the scale subresource, e.g. for the HorizontalPodAutoscaler.  # noqa: E501
+
+        :return: The selector of this V1TFJobStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._selector
+
+    @selector.setter
+    def selector(self, selector):
+        """Sets the selector of this V1TFJobStatus.
+
+        Selector is the label selector of the Worker pods of the TFJob, which is the selector of the scale subresource, e.g. for the HorizontalPodAutoscaler.  # noqa: E501
+
+        :param selector: The selector of this V1TFJobStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._selector = selector
+
+    @property
+    def start_time(self):
+        """Gets the start_time of this V1TFJobStatus.  # noqa: E501
+
+        Represents time when the job was acknowledged by the job controller. It is not guaranteed to be set in happens-before order across separate operations. It is represented in RFC3339 form and is in UTC.  # noqa: E501
+
+        :return: The start_time of this V1TFJobStatus.  # noqa: E501
+        :rtype: V1Time
+        """
+        return self._start_time
+
+    @start_time.setter
+    def start_time(self, start_time):
+        """Sets the start_time of this V1TFJobStatus.
+
+        Represents time when the job was acknowledged by the job controller. It is not guaranteed to be set in happens-before order across separate operations. It is represented in RFC3339 form and is in UTC.  # noqa: E501
+
+        :param start_time: The start_time of this V1TFJobStatus.  # noqa: E501
+        :type: V1Time
+        """
+
+        self._start_time = start_time
+
+    @property
+    def suspended_generation(self):
+        """Gets the suspended_generation of this V1TFJobStatus.  # noqa: E501
+
+        SuspendedGeneration is the generation of the TFJob when it is suspended by its PendingTimeoutPolicy. The TFJob is resumed once its spec is updated afterwards, e.g. when spec.suspend is set to false.  # noqa: E501
+
+        :return: The suspended_generation of this V1TFJobStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._suspended_generation
+
+    @suspended_generation.setter
+    def suspended_generation(self, suspended_generation):
+        """Sets the suspended_generation of this V1TFJobStatus.
+
+        SuspendedGeneration is the generation of the TFJob when it is suspended by its PendingTimeoutPolicy. The TFJob is resumed once its spec is updated afterwards, e.g. when spec.suspend is set to false.  # noqa: E501
+
+        :param suspended_generation: The suspended_generation of this V1TFJobStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._suspended_generation = suspended_generation
+
+    @property
+    def waiting_for(self):
+        """Gets the waiting_for of this V1TFJobStatus.  # noqa: E501
+
+        WaitingFor is the replica types which have not started yet, while the replicas of the later stages of the InOrder startup policy wait for them.  # noqa: E501
+
+        :return: The waiting_for of this V1TFJobStatus.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._waiting_for
+
+    @waiting_for.setter
+    def waiting_for(self, waiting_for):
+        """Sets the waiting_for of this V1TFJobStatus.
+
+        WaitingFor is the replica types which have not started yet, while the replicas of the later stages of the InOrder startup policy wait for them.  # noqa: E501
+
+        :param waiting_for: The waiting_for of this V1TFJobStatus.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._waiting_for = waiting_for
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.swagger_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+        if issubclass(V1TFJobStatus, dict):
+            for key, value in self.items():
+                result[key] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1TFJobStatus):
+            return False
+
+        return self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        return not self == other